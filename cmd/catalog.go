@@ -0,0 +1,83 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/matty-rose/gha-docs/pkg/generator"
+	"github.com/matty-rose/gha-docs/pkg/parser"
+	"github.com/matty-rose/gha-docs/pkg/writer"
+)
+
+// catalogCmd represents the catalog command
+var catalogCmd = &cobra.Command{
+	Use:   "catalog [DIR]",
+	Short: "Generate a summary table of every action found in a directory.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := parser.ParseAll(args[0])
+		if err != nil {
+			return err
+		}
+
+		g, err := generator.NewCatalog(generator.Config{Format: format})
+		if err != nil {
+			return errors.Wrap(err, "couldn't construct the generator")
+		}
+
+		return writer.Write(writer.WriteInputs{
+			Content:     g.GenerateCatalog(entries),
+			OutputFile:  outputFile,
+			Inject:      inject,
+			BeginMarker: writer.BeginCatalogInjection,
+			EndMarker:   writer.EndCatalogInjection,
+		})
+	},
+}
+
+func init() {
+	catalogCmd.PersistentFlags().StringVarP(
+		&format,
+		"format",
+		"f",
+		"markdown",
+		"Format to generate the catalog in - one of markdown or html.",
+	)
+	catalogCmd.PersistentFlags().StringVarP(
+		&outputFile,
+		"output-file",
+		"o",
+		"",
+		"File to write the generated catalog to.",
+	)
+	catalogCmd.PersistentFlags().BoolVarP(
+		&inject,
+		"inject",
+		"i",
+		false,
+		"Set flag to inject the generated catalog between catalog markers. Ignored if not writing to a file. "+
+			"Defaults to false.",
+	)
+	rootCmd.AddCommand(catalogCmd)
+}
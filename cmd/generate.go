@@ -22,12 +22,23 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/thediveo/enumflag"
 
+	"github.com/matty-rose/gha-docs/pkg/analysis"
 	"github.com/matty-rose/gha-docs/pkg/generator"
+	"github.com/matty-rose/gha-docs/pkg/graph"
 	"github.com/matty-rose/gha-docs/pkg/parser"
+	"github.com/matty-rose/gha-docs/pkg/resolver"
+	"github.com/matty-rose/gha-docs/pkg/types"
 	"github.com/matty-rose/gha-docs/pkg/writer"
 )
 
@@ -43,64 +54,496 @@ var inject bool
 // Usage mode flag
 var usageMode generator.UsageMode = generator.Remote
 
+// Example inputs flag
+var exampleInputs generator.ExampleInputsMode = generator.AllInputs
+
+// Example outputs flag
+var exampleOutputs bool
+
+// Example workflow flag
+var exampleWorkflow bool
+
+// Watch flag
+var watch bool
+
+// Transitive external actions flag
+var transitive bool
+
+// External action arguments flag
+var arguments bool
+
+// Steps flag
+var steps bool
+
+// Table column flags
+var (
+	inputColumns          []string
+	outputColumns         []string
+	externalActionColumns []string
+)
+
+// Heading flags
+var (
+	headingLevel int
+	omitHeader   bool
+)
+
+// Table of contents flag
+var tableOfContents bool
+
+// Warnings flag
+var warnings bool
+
+// Section flags
+var (
+	sections         []string
+	sectionTitles    map[string]string
+	sectionEmptyText map[string]string
+)
+
+// Fail on injection risk flag
+var failOnInjectionRisk bool
+
+// Action mirror flag
+var actionMirror string
+
+// Remote resolution flags
+var (
+	resolveRemote bool
+	githubAPIURL  string
+	cacheDir      string
+	offline       bool
+)
+
 // generateCmd represents the generate command
 var generateCmd = &cobra.Command{
 	Use:   "generate [PATH]",
 	Short: "Generate documentation for a composite GitHub action.",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		action, err := parser.Parse(args[0])
-		if err != nil {
-			return errors.Wrap(err, "couldn't parse the action file")
+		err := generate(args[0])
+
+		if watch {
+			// Keep watching if the first generation fails, so the action can be fixed.
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Generation failed: %s\n", err)
+			}
+
+			return watchAndGenerate(cmd.Context(), args[0])
 		}
 
-		var g generator.Generator
-		g, err = generator.New(generator.Config{
-			Format:           format,
-			ExampleUsageMode: &usageMode,
-		})
+		return err
+	},
+}
+
+func generate(actionFile string) error {
+	config, err := generatorConfig()
+	if err != nil {
+		return err
+	}
+
+	action, err := parser.Parse(actionFile)
+	if err != nil {
+		return errors.Wrap(err, "couldn't parse the action file")
+	}
+
+	if err = checkAction(action); err != nil {
+		return err
+	}
+
+	if r := newResolver(); r != nil {
+		resolver.ResolveAll(r, action)
+	}
+
+	if transitive {
+		action.TransitiveUses, err = graph.Transitive(repoRoot, action)
 		if err != nil {
-			return errors.Wrap(err, "couldn't construct the generator")
+			return errors.Wrap(err, "couldn't resolve transitive external actions")
 		}
+	}
 
-		content := g.Generate(action)
+	g, err := generator.New(config)
+	if err != nil {
+		return errors.Wrap(err, "couldn't construct the generator")
+	}
 
-		err = writer.Write(writer.WriteInputs{
-			Content:    content,
-			OutputFile: outputFile,
-			Inject:     inject,
-		})
-		return err
-	},
+	content, err := g.Generate(action)
+	if err != nil {
+		return errors.Wrap(err, "couldn't generate documentation")
+	}
+
+	err = writer.Write(writer.WriteInputs{
+		Content:    content,
+		OutputFile: outputFile,
+		Inject:     inject,
+	})
+
+	return err
+}
+
+// generatorConfig returns the generator configuration based on the flags passed, which are shared by every command
+// generating documentation for actions.
+func generatorConfig() (generator.Config, error) {
+	// The generator treats a heading level of zero as unset, but passing it by flag is a mistake.
+	if headingLevel < 1 {
+		return generator.Config{}, errors.Errorf(
+			"heading level must be between 1 and %d, got %d",
+			generator.MaxHeadingLevel,
+			headingLevel,
+		)
+	}
+
+	return generator.Config{
+		Format:                    format,
+		ExampleUsageMode:          &usageMode,
+		ExampleInputs:             exampleInputs,
+		ExampleOutputs:            exampleOutputs,
+		ExampleWorkflow:           exampleWorkflow,
+		TransitiveExternalActions: transitive,
+		ExternalActionArguments:   arguments,
+		Steps:                     steps,
+		TableOfContents:           tableOfContents,
+		HeadingLevel:              headingLevel,
+		OmitHeader:                omitHeader,
+		Warnings:                  warnings,
+		Sections:                  sectionList(sections),
+		SectionTitles:             sectionMap(sectionTitles),
+		SectionEmptyText:          sectionMap(sectionEmptyText),
+		InputColumns:              columnList(inputColumns),
+		OutputColumns:             columnList(outputColumns),
+		ExternalActionColumns:     columnList(externalActionColumns),
+	}, nil
+}
+
+// checkAction checks the action for problems before generating documentation, failing on injection risks if
+// requested, and logging a warning for each input whose default or choices don't match its type.
+func checkAction(action *types.CompositeAction) error {
+	if failOnInjectionRisk {
+		if err := checkInjectionRisks(action); err != nil {
+			return err
+		}
+	}
+
+	for _, problem := range analysis.CheckInputTypes(action) {
+		logrus.Warn(problem)
+	}
+
+	return nil
+}
+
+// newResolver returns a resolver for remote action metadata based on the flags passed, or nil if resolution isn't
+// enabled.
+func newResolver() resolver.Resolver {
+	var resolvers []resolver.Resolver
+
+	if actionMirror != "" {
+		resolvers = append(resolvers, resolver.NewLocal(actionMirror))
+	}
+
+	if resolveRemote {
+		token := os.Getenv("GITHUB_TOKEN")
+		if token == "" {
+			token = os.Getenv("GH_TOKEN")
+		}
+
+		resolvers = append(resolvers, resolver.NewGitHub(resolver.GitHubConfig{
+			BaseURL:  githubAPIURL,
+			Token:    token,
+			CacheDir: cacheDir,
+			Offline:  offline,
+		}))
+	}
+
+	if len(resolvers) == 0 {
+		return nil
+	}
+
+	return resolver.NewChain(resolvers...)
 }
 
 func init() {
-	generateCmd.PersistentFlags().StringVarP(
+	flags := generateCmd.PersistentFlags()
+
+	flags.StringVarP(
 		&format,
 		"format",
 		"f",
 		"markdown",
-		"Format to generate documentation in - currently only markdown is supported.",
+		"Format to generate documentation in - one of markdown or html.",
 	)
-	generateCmd.PersistentFlags().StringVarP(
+	flags.StringVarP(
 		&outputFile,
 		"output-file",
 		"o",
 		"",
 		"File to write generated documentation to.",
 	)
-	generateCmd.PersistentFlags().BoolVarP(
+	flags.BoolVarP(
 		&inject,
 		"inject",
 		"i",
 		false,
 		"Set flag to inject generated documentation between markers. Ignored if not writing to a file. Defaults to false.",
 	)
-	generateCmd.PersistentFlags().VarP(
+	flags.BoolVarP(
+		&watch,
+		"watch",
+		"w",
+		false,
+		"Set flag to watch the action file, and regenerate documentation whenever it changes.",
+	)
+	flags.BoolVar(
+		&failOnInjectionRisk,
+		"fail-on-injection-risk",
+		false,
+		"Set flag to fail without writing documentation if any input is interpolated directly into a run script.",
+	)
+
+	addExampleFlags(flags)
+	addSectionFlags(flags)
+	addTableFlags(flags)
+	addResolverFlags(flags)
+	rootCmd.AddCommand(generateCmd)
+}
+
+// addExampleFlags registers the flags configuring the example usage block.
+func addExampleFlags(flags *pflag.FlagSet) {
+	flags.VarP(
 		enumflag.New(&usageMode, "mode", generator.UsageModeIDs, enumflag.EnumCaseInsensitive),
 		"usage-mode",
 		"u",
 		"Sets the usage mode when generating example usage block. Must be one of 'remote' or 'local'.",
 	)
-	rootCmd.AddCommand(generateCmd)
+	flags.Var(
+		enumflag.New(&exampleInputs, "mode", generator.ExampleInputsModeIDs, enumflag.EnumCaseInsensitive),
+		"example-inputs",
+		"Sets which inputs are included in the example usage block. Must be one of 'all', 'required', 'defaults' "+
+			"or 'commented'.",
+	)
+	flags.BoolVar(
+		&exampleOutputs,
+		"example-outputs",
+		false,
+		"Set flag to add a step reading each of the action's outputs to the example usage block.",
+	)
+	flags.BoolVar(
+		&exampleWorkflow,
+		"example-workflow",
+		false,
+		"Set flag to wrap the example usage block in a complete workflow.",
+	)
+}
+
+// addSectionFlags registers the flags configuring which sections are generated and their headings.
+func addSectionFlags(flags *pflag.FlagSet) {
+	flags.IntVar(
+		&headingLevel,
+		"heading-level",
+		1,
+		"Level of the action's name heading, with every other heading shifted to match, from 1 to 4.",
+	)
+	flags.BoolVar(
+		&omitHeader,
+		"omit-header",
+		false,
+		"Set flag to leave out the action's name and description.",
+	)
+	flags.BoolVar(
+		&tableOfContents,
+		"toc",
+		false,
+		"Set flag to add a table of contents linking to each section after the description.",
+	)
+	flags.BoolVar(
+		&steps,
+		"steps",
+		false,
+		"Set flag to add a section summarising each step of the action.",
+	)
+	flags.BoolVar(
+		&warnings,
+		"warnings",
+		false,
+		"Set flag to add a section listing problems found in the action's run steps.",
+	)
+	flags.StringSliceVar(
+		&sections,
+		"sections",
+		nil,
+		"Sections to include, in order, from inputs, outputs, external-actions, steps, warnings and example-usage. "+
+			"Overrides --steps and --warnings.",
+	)
+	flags.StringToStringVar(
+		&sectionTitles,
+		"section-titles",
+		nil,
+		"Headings of sections, e.g. example-usage=Usage.",
+	)
+	flags.StringToStringVar(
+		&sectionEmptyText,
+		"section-empty-text",
+		nil,
+		"Text shown in sections with nothing to list, e.g. outputs='This action has no outputs.'.",
+	)
+}
+
+// addTableFlags registers the flags configuring the columns of the generated tables.
+func addTableFlags(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&inputColumns,
+		"input-columns",
+		nil,
+		"Columns of the inputs table, in order, from name, description, type, required, default and "+
+			"security-notes. "+
+			columnFlagUsage,
+	)
+	flags.StringSliceVar(
+		&outputColumns,
+		"output-columns",
+		nil,
+		"Columns of the outputs table, in order, from name, description and value. "+columnFlagUsage,
+	)
+	flags.StringSliceVar(
+		&externalActionColumns,
+		"external-action-columns",
+		nil,
+		"Columns of the external actions table, in order, from name, description, creator, version, step-name, "+
+			"step-id, inputs-valid and arguments. "+columnFlagUsage,
+	)
+	flags.BoolVar(
+		&arguments,
+		"arguments",
+		false,
+		"Set flag to show the inputs and environment variables passed to each external action.",
+	)
+}
+
+// addResolverFlags registers the flags configuring how local and remote actions used by the action are resolved.
+func addResolverFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(
+		&repoRoot,
+		"root",
+		"r",
+		".",
+		"Root of the repository, which local action references are resolved relative to.",
+	)
+	flags.BoolVarP(
+		&transitive,
+		"transitive",
+		"t",
+		false,
+		"Set flag to list every remote action used, including through local actions, in the external actions section.",
+	)
+	flags.StringVar(
+		&actionMirror,
+		"action-mirror",
+		"",
+		"Directory holding copies of remote actions, laid out as <owner>/<repo>/<ref> or <owner>/<repo>, "+
+			"used to validate the inputs passed to them.",
+	)
+	flags.BoolVar(
+		&resolveRemote,
+		"resolve-remote",
+		false,
+		"Set flag to fetch the metadata of remote actions through the GitHub API, authenticating with GITHUB_TOKEN "+
+			"or GH_TOKEN if set.",
+	)
+	flags.StringVar(
+		&githubAPIURL,
+		"github-api-url",
+		defaultGitHubAPIURL(),
+		"Base URL of the GitHub API, defaulting to GITHUB_API_URL if set.",
+	)
+	flags.StringVar(
+		&cacheDir,
+		"cache-dir",
+		defaultCacheDir(),
+		"Directory to cache remote action metadata in. Set to an empty string to disable caching.",
+	)
+	flags.BoolVar(
+		&offline,
+		"offline",
+		false,
+		"Set flag to only resolve remote actions from the cache.",
+	)
+}
+
+// sectionList converts the sections passed by flag to generator sections.
+func sectionList(names []string) []generator.Section {
+	list := make([]generator.Section, 0, len(names))
+	for _, name := range names {
+		list = append(list, generator.Section(name))
+	}
+
+	return list
+}
+
+// sectionMap converts a mapping of section names passed by flag to a mapping of generator sections.
+func sectionMap(values map[string]string) map[generator.Section]string {
+	mapping := make(map[generator.Section]string, len(values))
+	for name, value := range values {
+		mapping[generator.Section(name)] = value
+	}
+
+	return mapping
+}
+
+const columnFlagUsage = "Each column is given as ID[:FORMAT][=HEADER], where FORMAT is check or yes-no for the " +
+	"required column, e.g. required:check=Required?."
+
+// columnList converts the columns passed by flag, given as ID[:FORMAT][=HEADER], to generator columns.
+func columnList(specs []string) []generator.Column {
+	columns := make([]generator.Column, 0, len(specs))
+
+	for _, spec := range specs {
+		var column generator.Column
+
+		if idx := strings.Index(spec, "="); idx != -1 {
+			spec, column.Header = spec[:idx], spec[idx+1:]
+		}
+
+		if idx := strings.Index(spec, ":"); idx != -1 {
+			spec, column.Format = spec[:idx], spec[idx+1:]
+		}
+
+		column.ID = spec
+		columns = append(columns, column)
+	}
+
+	return columns
+}
+
+// checkInjectionRisks returns an error naming every input of the action interpolated directly into a run script.
+func checkInjectionRisks(action *types.CompositeAction) error {
+	risks := analysis.InjectionRisks(action)
+	if len(risks) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(risks))
+	for _, risk := range risks {
+		names = append(names, risk.Input)
+	}
+
+	return errors.Errorf(
+		"inputs interpolated directly into run scripts, risking script injection: %s",
+		strings.Join(names, ", "),
+	)
+}
+
+func defaultGitHubAPIURL() string {
+	if apiURL := os.Getenv("GITHUB_API_URL"); apiURL != "" {
+		return apiURL
+	}
+
+	return resolver.DefaultGitHubAPIURL
+}
+
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "gha-docs", "actions")
 }
@@ -0,0 +1,89 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/matty-rose/gha-docs/pkg/graph"
+	"github.com/matty-rose/gha-docs/pkg/writer"
+)
+
+// Graph format flag
+var graphFormat string
+
+// Repository root flag
+var repoRoot string
+
+// graphCmd represents the graph command
+var graphCmd = &cobra.Command{
+	Use:   "graph [PATH]...",
+	Short: "Output the dependency graph of one or more GitHub actions, following local actions recursively.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g, err := graph.Build(repoRoot, args...)
+		if err != nil {
+			return errors.Wrap(err, "couldn't build the dependency graph")
+		}
+
+		for _, cycle := range g.Cycles {
+			logrus.Warnf("dependency cycle found: %s", strings.Join(cycle, " -> "))
+		}
+
+		content, err := graph.Render(g, graphFormat)
+		if err != nil {
+			return err
+		}
+
+		return writer.Write(writer.WriteInputs{Content: content, OutputFile: outputFile})
+	},
+}
+
+func init() {
+	graphCmd.PersistentFlags().StringVarP(
+		&graphFormat,
+		"format",
+		"f",
+		"dot",
+		fmt.Sprintf("Format to output the graph in - one of %s.", strings.Join(graph.Formats, ", ")),
+	)
+	graphCmd.PersistentFlags().StringVarP(
+		&outputFile,
+		"output-file",
+		"o",
+		"",
+		"File to write the graph to.",
+	)
+	graphCmd.PersistentFlags().StringVarP(
+		&repoRoot,
+		"root",
+		"r",
+		".",
+		"Root of the repository, which local action references are resolved relative to.",
+	)
+	rootCmd.AddCommand(graphCmd)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/matty-rose/gha-docs/pkg/analysis"
+	"github.com/matty-rose/gha-docs/pkg/parser"
+)
+
+// lintCmd represents the lint command
+var lintCmd = &cobra.Command{
+	Use:   "lint [PATH]...",
+	Short: "Check the run steps and inputs of one or more GitHub actions for common problems.",
+	Long: `Check the run steps of one or more GitHub actions for common problems, such as expressions interpolated
+directly into scripts, run steps without a shell, and deprecated workflow commands, and check the defaults of inputs
+with a declared type against that type.
+
+Findings are printed one per line, and the command fails if there are any.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		count := 0
+
+		for _, actionFile := range args {
+			action, err := parser.Parse(actionFile)
+			if err != nil {
+				return errors.Wrapf(err, "couldn't parse %s", actionFile)
+			}
+
+			for _, finding := range analysis.Analyze(action) {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", actionFile, finding)
+				count++
+			}
+
+			for _, problem := range analysis.CheckInputTypes(action) {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: %s\n", actionFile, problem)
+				count++
+			}
+		}
+
+		if count != 0 {
+			return errors.Errorf("found %d problem(s)", count)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+}
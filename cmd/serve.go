@@ -0,0 +1,112 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/matty-rose/gha-docs/pkg/generator"
+	"github.com/matty-rose/gha-docs/pkg/server"
+	"github.com/matty-rose/gha-docs/pkg/watcher"
+)
+
+// Address flag
+var address string
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve [PATH]...",
+	Short: "Serve a live-reloading HTML preview of the documentation for one or more composite GitHub actions.",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := generatorConfig()
+		if err != nil {
+			return err
+		}
+
+		// The server renders the markdown as HTML itself.
+		config.Format = "markdown"
+
+		g, err := generator.New(config)
+		if err != nil {
+			return errors.Wrap(err, "couldn't construct the generator")
+		}
+
+		w, err := watcher.New(args, watcher.DefaultDebounce)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+		defer stop()
+
+		s := server.New(args, g)
+		httpServer := &http.Server{Addr: address, Handler: s, ReadHeaderTimeout: 10 * time.Second}
+
+		go func() {
+			_ = w.Run(ctx, func(changed []string) {
+				fmt.Fprintf(os.Stderr, "Reloading after changes to %s\n", describeFiles(changed))
+				s.Reload()
+			})
+		}()
+
+		go func() {
+			<-ctx.Done()
+
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			_ = httpServer.Shutdown(shutdownCtx)
+		}()
+
+		fmt.Fprintf(os.Stderr, "Serving documentation at http://%s, press Ctrl+C to stop\n", address)
+
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return errors.Wrap(err, "couldn't serve documentation")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	serveCmd.PersistentFlags().StringVarP(
+		&address,
+		"address",
+		"a",
+		"localhost:8080",
+		"Address to serve the documentation on.",
+	)
+
+	addExampleFlags(serveCmd.PersistentFlags())
+	addSectionFlags(serveCmd.PersistentFlags())
+	addTableFlags(serveCmd.PersistentFlags())
+	rootCmd.AddCommand(serveCmd)
+}
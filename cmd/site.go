@@ -0,0 +1,50 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/matty-rose/gha-docs/pkg/site"
+)
+
+// siteCmd represents the site command
+var siteCmd = &cobra.Command{
+	Use:   "site [DIR] [OUT]",
+	Short: "Generate a static HTML site documenting every action found in a directory.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := generatorConfig()
+		if err != nil {
+			return err
+		}
+
+		return site.Build(args[0], args[1], config)
+	},
+}
+
+func init() {
+	addExampleFlags(siteCmd.PersistentFlags())
+	addSectionFlags(siteCmd.PersistentFlags())
+	addTableFlags(siteCmd.PersistentFlags())
+	rootCmd.AddCommand(siteCmd)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/matty-rose/gha-docs/pkg/examples"
+	"github.com/matty-rose/gha-docs/pkg/parser"
+)
+
+// Action references flag
+var actionReferences []string
+
+// verifyExamplesCmd represents the verify-examples command
+var verifyExamplesCmd = &cobra.Command{
+	Use:   "verify-examples MARKDOWN ACTION",
+	Short: "Check the examples of using a GitHub action in a markdown file against the action.",
+	Long: `Check the examples of using a GitHub action in a markdown file, such as a README, against the action.
+
+Every YAML code block with a step using the action is checked for unknown inputs, missing required inputs and
+references to outputs the action doesn't have. Steps use the action if they reference it by one of the references
+given by --uses, ignoring the ref after the @, or by a local path resolving to the action file.
+
+Problems are printed one per line, and the command fails if there are any, or if no examples use the action, as the
+references given by --uses are probably wrong.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		markdownFile, actionFile := args[0], args[1]
+
+		action, err := parser.Parse(actionFile)
+		if err != nil {
+			return errors.Wrap(err, "couldn't parse the action file")
+		}
+
+		markdown, err := ioutil.ReadFile(markdownFile)
+		if err != nil {
+			return errors.Wrap(err, "couldn't read the markdown file")
+		}
+
+		report := examples.Verify(string(markdown), action, usesAction(actionFile))
+		if report.Steps == 0 {
+			return errors.Errorf(
+				"no examples in %s use the action by any of the references %s - pass the references used with --uses",
+				markdownFile,
+				strings.Join(actionReferences, ", "),
+			)
+		}
+
+		for _, problem := range report.Problems {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s:%d: %s\n", markdownFile, problem.Line, problem.Message)
+		}
+
+		if len(report.Problems) != 0 {
+			return errors.Errorf("found %d problem(s)", len(report.Problems))
+		}
+
+		return nil
+	},
+}
+
+// usesAction returns a function reporting whether a uses reference is to the action file, either by one of the
+// references given by flag or by a local path resolving to it.
+func usesAction(actionFile string) func(uses string) bool {
+	matches := examples.UsesMatcher(actionReferences...)
+	target, _ := filepath.Abs(actionFile)
+
+	return func(uses string) bool {
+		if matches(uses) {
+			return true
+		}
+
+		if !strings.HasPrefix(uses, "./") {
+			return false
+		}
+
+		file, ok := parser.ResolveLocal(repoRoot, uses)
+		if !ok {
+			return false
+		}
+
+		file, _ = filepath.Abs(file)
+
+		return file == target
+	}
+}
+
+func init() {
+	verifyExamplesCmd.PersistentFlags().StringSliceVar(
+		&actionReferences,
+		"uses",
+		[]string{"owner/repo", "./path/to/action.yml"},
+		"References to the action used in examples, e.g. owner/repo or owner/repo/path. Defaults to the references "+
+			"used in generated documentation.",
+	)
+	verifyExamplesCmd.PersistentFlags().StringVarP(
+		&repoRoot,
+		"root",
+		"r",
+		".",
+		"Root of the repository, which local action references are resolved relative to.",
+	)
+	rootCmd.AddCommand(verifyExamplesCmd)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/matty-rose/gha-docs/pkg/graph"
+	"github.com/matty-rose/gha-docs/pkg/watcher"
+)
+
+// watchAndGenerate regenerates documentation for the action file whenever it changes, until interrupted. When
+// resolving transitive external actions, the files of the local actions it uses are watched too, and the watch list
+// is updated as they change.
+func watchAndGenerate(ctx context.Context, actionFile string) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	files := watchedFiles(actionFile)
+
+	for {
+		w, err := watcher.New(files, watcher.DefaultDebounce)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Watching %d file(s) for changes, press Ctrl+C to stop\n", len(w.Files()))
+
+		runCtx, cancel := context.WithCancel(ctx)
+
+		var updated []string
+
+		err = w.Run(runCtx, func(changed []string) {
+			if err := generate(actionFile); err != nil {
+				fmt.Fprintf(os.Stderr, "Regeneration failed: %s\n", err)
+			} else {
+				fmt.Fprintf(os.Stderr, "Regenerated documentation after changes to %s\n", describeFiles(changed))
+			}
+
+			// Restart the watcher if the local actions used have changed.
+			if next := watchedFiles(actionFile); !equalFiles(files, next) {
+				updated = next
+
+				cancel()
+			}
+		})
+
+		cancel()
+
+		if err != nil || updated == nil {
+			return err
+		}
+
+		files = updated
+	}
+}
+
+// watchedFiles returns the files to watch for the action file, which includes the files of the local actions it uses
+// when resolving transitive external actions. Only the action file is watched if the local actions can't be found,
+// e.g. as the action file is invalid.
+func watchedFiles(actionFile string) []string {
+	if !transitive {
+		return []string{actionFile}
+	}
+
+	g, err := graph.Build(repoRoot, actionFile)
+	if err != nil {
+		return []string{actionFile}
+	}
+
+	return g.LocalFiles()
+}
+
+func equalFiles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func describeFiles(files []string) string {
+	if len(files) == 1 {
+		return filepath.Base(files[0])
+	}
+
+	return fmt.Sprintf("%d files", len(files))
+}
@@ -0,0 +1,177 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package analysis
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/matty-rose/gha-docs/pkg/expression"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+)
+
+// Rules checked by Analyze.
+const (
+	RuleExpressionInjection = "expression-injection"
+	RuleMissingShell        = "missing-shell"
+	RuleDeprecatedCommand   = "deprecated-command"
+)
+
+var deprecatedCommandRegex = regexp.MustCompile(`::(set-output|save-state|set-env|add-path)\b`)
+
+// deprecatedCommandReplacements maps each deprecated workflow command to what should be used instead.
+var deprecatedCommandReplacements = map[string]string{
+	"set-output": "$GITHUB_OUTPUT",
+	"save-state": "$GITHUB_STATE",
+	"set-env":    "$GITHUB_ENV",
+	"add-path":   "$GITHUB_PATH",
+}
+
+// untrustedContexts are the context paths whose values a caller of the action, or an attacker through the event
+// which triggered the workflow, can control, so aren't safe to interpolate into scripts. "*" matches any key.
+var untrustedContexts = [][]string{
+	{"inputs"},
+	{"github", "event"},
+	{"github", "head_ref"},
+	{"steps", "*", "outputs"},
+	{"env"},
+}
+
+// Finding is a single problem found in a step of an action.
+type Finding struct {
+	// Step is the index of the step the problem was found in.
+	Step     int
+	StepName string
+	// Line is the line of the step's script the problem was found on, or zero if it isn't specific to a line.
+	Line     int
+	Rule     string
+	Severity Severity
+	Message  string
+}
+
+func (f Finding) String() string {
+	location := fmt.Sprintf("step %d (%s)", f.Step+1, f.StepName)
+	if f.Line != 0 {
+		location = fmt.Sprintf("%s, line %d", location, f.Line)
+	}
+
+	return fmt.Sprintf("%s: %s [%s]: %s", location, f.Severity, f.Rule, f.Message)
+}
+
+// Analyze checks the run steps of an action for common problems, returning the findings in step order, then line
+// order.
+func Analyze(action *types.CompositeAction) []Finding {
+	var findings []Finding
+
+	for idx, step := range action.Steps {
+		if step.Run == "" {
+			continue
+		}
+
+		var stepFindings []Finding
+
+		finding := Finding{Step: idx, StepName: step.DisplayName()}
+
+		if step.Shell == "" {
+			finding.Rule = RuleMissingShell
+			finding.Severity = Error
+			finding.Message = "run steps in composite actions must set shell"
+			stepFindings = append(stepFindings, finding)
+		}
+
+		// Expressions can span lines, so the whole script is searched at once.
+		for _, expr := range expression.FindAll(step.Run) {
+			if expr.Err != nil || !isUntrusted(expr) {
+				continue
+			}
+
+			finding.Line = strings.Count(step.Run[:expr.Offset], "\n") + 1
+			finding.Rule = RuleExpressionInjection
+			finding.Severity = Warning
+			finding.Message = fmt.Sprintf(
+				"%s is interpolated directly into the script, which allows injection - pass it in through env instead",
+				expr.Raw,
+			)
+			stepFindings = append(stepFindings, finding)
+		}
+
+		for lineIdx, line := range strings.Split(step.Run, "\n") {
+			finding.Line = lineIdx + 1
+
+			for _, match := range deprecatedCommandRegex.FindAllStringSubmatch(line, -1) {
+				finding.Rule = RuleDeprecatedCommand
+				finding.Severity = Warning
+				finding.Message = fmt.Sprintf(
+					"the ::%s command is deprecated - write to %s instead",
+					match[1],
+					deprecatedCommandReplacements[match[1]],
+				)
+				stepFindings = append(stepFindings, finding)
+			}
+		}
+
+		sort.SliceStable(stepFindings, func(a, b int) bool {
+			return stepFindings[a].Line < stepFindings[b].Line
+		})
+
+		findings = append(findings, stepFindings...)
+	}
+
+	return findings
+}
+
+// isUntrusted returns whether an expression refers to a context which a caller or attacker can control, including
+// through a whole object containing it, such as toJSON(github).
+func isUntrusted(expr expression.Expression) bool {
+	for _, ref := range expr.References() {
+		path := append([]string{ref.Context}, ref.Path...)
+
+		for _, untrusted := range untrustedContexts {
+			if matchesPrefix(path, untrusted) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchesPrefix returns whether one of path and pattern is a prefix of the other, where "*" in the pattern matches
+// any key.
+func matchesPrefix(path, pattern []string) bool {
+	for idx := 0; idx < len(path) && idx < len(pattern); idx++ {
+		if pattern[idx] != "*" && pattern[idx] != path[idx] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,193 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/analysis"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+func TestAnalyzeClean(t *testing.T) {
+	t.Parallel()
+
+	action := types.CompositeAction{
+		Steps: []types.Step{
+			{Name: "Checkout", Uses: "actions/checkout@v2"},
+			{
+				Name:  "Greet",
+				Shell: "bash",
+				Env:   map[string]string{"NAME": "${{ inputs.name }}"},
+				Run:   "echo \"hello $NAME\"\necho \"greeting=hello\" >> \"$GITHUB_OUTPUT\"\n",
+			},
+		},
+	}
+
+	assert.Empty(t, analysis.Analyze(&action))
+}
+
+func TestAnalyzeMissingShell(t *testing.T) {
+	t.Parallel()
+
+	action := types.CompositeAction{
+		Steps: []types.Step{
+			{Name: "Checkout", Uses: "actions/checkout@v2"},
+			{ID: "greet", Run: "echo hello"},
+		},
+	}
+
+	assert.Equal(
+		t,
+		[]analysis.Finding{
+			{
+				Step:     1,
+				StepName: "Run echo hello",
+				Rule:     analysis.RuleMissingShell,
+				Severity: analysis.Error,
+				Message:  "run steps in composite actions must set shell",
+			},
+		},
+		analysis.Analyze(&action),
+	)
+}
+
+func TestAnalyzeExpressionInjection(t *testing.T) {
+	t.Parallel()
+
+	action := types.CompositeAction{
+		Steps: []types.Step{
+			{
+				Name:  "Greet",
+				Shell: "bash",
+				Run:   "set -e\necho ${{ inputs.greeting }} ${{ github.event.issue.title }}\n",
+			},
+		},
+	}
+
+	findings := analysis.Analyze(&action)
+	if assert.Len(t, findings, 2) {
+		assert.Equal(t, analysis.RuleExpressionInjection, findings[0].Rule)
+		assert.Equal(t, analysis.Warning, findings[0].Severity)
+		assert.Equal(t, 2, findings[0].Line)
+		assert.Contains(t, findings[0].Message, "${{ inputs.greeting }}")
+		assert.Contains(t, findings[1].Message, "${{ github.event.issue.title }}")
+	}
+}
+
+func TestAnalyzeExpressionInjectionTrustedContexts(t *testing.T) {
+	t.Parallel()
+
+	action := types.CompositeAction{
+		Steps: []types.Step{
+			{
+				Name:  "Run script",
+				Shell: "bash",
+				Run: "${{ github.action_path }}/script.sh ${{ runner.os }} ${{ github.sha }}\n" +
+					"echo ${{ steps.build.outcome }} ${{ 1 + }}\n",
+			},
+		},
+	}
+
+	assert.Empty(t, analysis.Analyze(&action))
+}
+
+func TestAnalyzeExpressionInjectionUntrustedContexts(t *testing.T) {
+	t.Parallel()
+
+	action := types.CompositeAction{
+		Steps: []types.Step{
+			{
+				Name:  "Greet",
+				Shell: "bash",
+				Run: "echo ${{ github.head_ref }}\necho ${{ steps.meta.outputs.tags }} ${{ env.TITLE }}\n" +
+					"echo ${{ toJSON(github) }} ${{ format('{0}',\n  inputs.name) }}\n",
+			},
+		},
+	}
+
+	var lines []int
+
+	for _, finding := range analysis.Analyze(&action) {
+		assert.Equal(t, analysis.RuleExpressionInjection, finding.Rule)
+		lines = append(lines, finding.Line)
+	}
+
+	assert.Equal(t, []int{1, 2, 2, 3, 3}, lines)
+}
+
+func TestAnalyzeDeprecatedCommand(t *testing.T) {
+	t.Parallel()
+
+	action := types.CompositeAction{
+		Steps: []types.Step{
+			{
+				Name:  "Output",
+				Shell: "bash",
+				Run:   "echo \"::set-output name=a::b\"\necho \"::save-state name=c::d\"\necho \"::set-outputs\"\n",
+			},
+		},
+	}
+
+	assert.Equal(
+		t,
+		[]analysis.Finding{
+			{
+				Step:     0,
+				StepName: "Output",
+				Line:     1,
+				Rule:     analysis.RuleDeprecatedCommand,
+				Severity: analysis.Warning,
+				Message:  "the ::set-output command is deprecated - write to $GITHUB_OUTPUT instead",
+			},
+			{
+				Step:     0,
+				StepName: "Output",
+				Line:     2,
+				Rule:     analysis.RuleDeprecatedCommand,
+				Severity: analysis.Warning,
+				Message:  "the ::save-state command is deprecated - write to $GITHUB_STATE instead",
+			},
+		},
+		analysis.Analyze(&action),
+	)
+}
+
+func TestFindingString(t *testing.T) {
+	t.Parallel()
+
+	finding := analysis.Finding{
+		Step:     2,
+		StepName: "Greet",
+		Line:     4,
+		Rule:     analysis.RuleExpressionInjection,
+		Severity: analysis.Warning,
+		Message:  "bad",
+	}
+
+	assert.Equal(t, "step 3 (Greet), line 4: warning [expression-injection]: bad", finding.String())
+
+	finding.Line = 0
+	assert.Equal(t, "step 3 (Greet): warning [expression-injection]: bad", finding.String())
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package analysis
+
+import (
+	"sort"
+
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+// InjectionRisk is an input which is interpolated directly into the script of one or more run steps, so could be
+// used to inject commands if untrusted data is passed to it.
+type InjectionRisk struct {
+	Input string
+	// Steps are the indices of the steps the input is interpolated into.
+	Steps []int
+}
+
+// InjectionRisks returns the inputs which are interpolated directly into run scripts, sorted by name.
+func InjectionRisks(action *types.CompositeAction) []InjectionRisk {
+	steps := map[string][]int{}
+
+	for idx, step := range action.Steps {
+		seen := map[string]bool{}
+
+		for _, ref := range step.References {
+			if ref.Field != "run" || !ref.Is("inputs") || len(ref.Path) == 0 {
+				continue
+			}
+
+			name := ref.Path[0]
+			if !seen[name] {
+				seen[name] = true
+				steps[name] = append(steps[name], idx)
+			}
+		}
+	}
+
+	risks := make([]InjectionRisk, 0, len(steps))
+	for name, idxs := range steps {
+		risks = append(risks, InjectionRisk{Input: name, Steps: idxs})
+	}
+
+	sort.Slice(risks, func(a, b int) bool {
+		return risks[a].Input < risks[b].Input
+	})
+
+	return risks
+}
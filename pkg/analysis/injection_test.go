@@ -0,0 +1,81 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/analysis"
+	"github.com/matty-rose/gha-docs/pkg/parser"
+)
+
+func TestInjectionRisks(t *testing.T) {
+	t.Parallel()
+
+	action, err := parser.ParseBytes([]byte(`
+runs:
+  using: composite
+  steps:
+    - shell: bash
+      env:
+        SAFE: ${{ inputs.safe }}
+      run: |
+        echo "${{ inputs.title }}" ${{ inputs.title }}
+        echo $SAFE ${{ github.event.inputs.other }}
+    - uses: actions/checkout@v2
+      with:
+        ref: ${{ inputs.ref }}
+    - shell: bash
+      run: git checkout ${{ inputs['ref'] }} && echo ${{ format('{0}', inputs.title) }}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(
+		t,
+		[]analysis.InjectionRisk{
+			{Input: "ref", Steps: []int{2}},
+			{Input: "title", Steps: []int{0, 2}},
+		},
+		analysis.InjectionRisks(action),
+	)
+}
+
+func TestInjectionRisksNone(t *testing.T) {
+	t.Parallel()
+
+	action, err := parser.ParseBytes([]byte(`
+runs:
+  using: composite
+  steps:
+    - shell: bash
+      run: echo ${{ github.sha }}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, analysis.InjectionRisks(action))
+}
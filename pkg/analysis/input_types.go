@@ -0,0 +1,102 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/matty-rose/gha-docs/pkg/expression"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+// booleanValues are the values the runner accepts for boolean inputs.
+var booleanValues = []string{"true", "True", "TRUE", "false", "False", "FALSE"}
+
+// InputTypeProblem is an input whose default doesn't match its declared type.
+type InputTypeProblem struct {
+	Input   string
+	Message string
+}
+
+func (p InputTypeProblem) String() string {
+	return fmt.Sprintf("input %s: %s", p.Input, p.Message)
+}
+
+// CheckInputTypes checks the default of each input with a declared type against that type, returning the problems
+// found sorted by input name. Defaults containing expressions are only known at runtime, so aren't checked.
+func CheckInputTypes(action *types.CompositeAction) []InputTypeProblem {
+	var problems []InputTypeProblem
+
+	for _, input := range action.Inputs {
+		if input.Default == "" || len(expression.FindAll(input.Default)) != 0 {
+			continue
+		}
+
+		if message := checkInputType(input); message != "" {
+			problems = append(problems, InputTypeProblem{Input: input.Name, Message: message})
+		}
+	}
+
+	sort.Slice(problems, func(a, b int) bool {
+		return problems[a].Input < problems[b].Input
+	})
+
+	return problems
+}
+
+func checkInputType(input types.Input) string {
+	switch input.Type {
+	case types.BooleanInput:
+		if !contains(booleanValues, input.Default) {
+			return fmt.Sprintf("default %q isn't a boolean", input.Default)
+		}
+	case types.NumberInput:
+		if _, err := strconv.ParseFloat(strings.TrimSpace(input.Default), 64); err != nil {
+			return fmt.Sprintf("default %q isn't a number", input.Default)
+		}
+	case types.ChoiceInput:
+		if !contains(input.Choices, input.Default) {
+			return fmt.Sprintf("default %q isn't one of %s", input.Default, strings.Join(input.Choices, ", "))
+		}
+	case types.JSONInput:
+		if !json.Valid([]byte(input.Default)) {
+			return fmt.Sprintf("default %q isn't valid JSON", input.Default)
+		}
+	case types.StringInput, types.ListInput:
+	}
+
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
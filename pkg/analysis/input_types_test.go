@@ -0,0 +1,81 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/analysis"
+	"github.com/matty-rose/gha-docs/pkg/parser"
+)
+
+func TestCheckInputTypes(t *testing.T) {
+	t.Parallel()
+
+	action, err := parser.ParseBytes([]byte(`
+inputs:
+  # @type boolean
+  debug:
+    default: "yes"
+  # @type boolean
+  verbose:
+    default: "False"
+  # @type number
+  retries:
+    default: three
+  # @type number
+  timeout:
+    default: ${{ github.event.inputs.timeout }}
+  # @choices debug, info
+  log-level:
+    default: warning
+  # @type json
+  config:
+    default: "{"
+  # @type json
+  labels:
+    default: '["bug"]'
+  # @type list
+  paths:
+    default: |
+      dist
+      build
+  name:
+    default: "anything"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(
+		t,
+		[]analysis.InputTypeProblem{
+			{Input: "config", Message: `default "{" isn't valid JSON`},
+			{Input: "debug", Message: `default "yes" isn't a boolean`},
+			{Input: "log-level", Message: `default "warning" isn't one of debug, info`},
+			{Input: "retries", Message: `default "three" isn't a number`},
+		},
+		analysis.CheckInputTypes(action),
+	)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package document
+
+import "strings"
+
+// CodeBlock is a fenced code block in a markdown document.
+type CodeBlock struct {
+	// Language is the first word of the info string after the opening fence, e.g. yaml.
+	Language string
+	Content  string
+	// Line is the line of the document the content starts on.
+	Line int
+}
+
+// FindCodeBlocks returns every fenced code block in a markdown document. Fences can use backticks or tildes, and be
+// indented, e.g. in a list item, in which case the indentation is removed from the content. Unterminated code blocks
+// run to the end of the document.
+func FindCodeBlocks(markdown string) []CodeBlock {
+	var blocks []CodeBlock
+
+	lines := strings.Split(markdown, "\n")
+
+	for idx := 0; idx < len(lines); idx++ {
+		indent, fence, info, ok := parseFence(lines[idx])
+		if !ok {
+			continue
+		}
+
+		block := CodeBlock{Line: idx + 2}
+		if fields := strings.Fields(info); len(fields) != 0 {
+			block.Language = fields[0]
+		}
+
+		var content []string
+
+		for idx++; idx < len(lines); idx++ {
+			if _, closing, info, ok := parseFence(lines[idx]); ok && info == "" &&
+				closing[0] == fence[0] && len(closing) >= len(fence) {
+				break
+			}
+
+			content = append(content, strings.TrimPrefix(lines[idx], indent))
+		}
+
+		if len(content) != 0 {
+			block.Content = strings.Join(content, "\n") + "\n"
+		}
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
+
+// parseFence parses a line as a code fence, returning its indentation, the fence itself and the info string after it.
+func parseFence(line string) (indent, fence, info string, ok bool) {
+	trimmed := strings.TrimLeft(line, " \t")
+	indent = line[:len(line)-len(trimmed)]
+
+	for _, char := range []string{"`", "~"} {
+		if !strings.HasPrefix(trimmed, strings.Repeat(char, 3)) {
+			continue
+		}
+
+		fence = trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, char))]
+		info = strings.TrimSpace(trimmed[len(fence):])
+
+		// Info strings of backtick fences can't contain backticks, so that they aren't confused with inline code.
+		if char == "`" && strings.Contains(info, "`") {
+			return "", "", "", false
+		}
+
+		return indent, fence, info, true
+	}
+
+	return "", "", "", false
+}
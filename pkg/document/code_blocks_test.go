@@ -0,0 +1,63 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package document_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/document"
+)
+
+func TestFindCodeBlocks(t *testing.T) {
+	t.Parallel()
+
+	markdown := "# Title\n" +
+		"```yaml\n" +
+		"a: b\n" +
+		"```\n" +
+		"\n" +
+		"- item\n" +
+		"  ~~~~ yml title\n" +
+		"  c: d\n" +
+		"  ~~~\n" +
+		"    e: f\n" +
+		"  ~~~~\n" +
+		"```\n" +
+		"```\n" +
+		"Inline ```code``` isn't a fence.\n" +
+		"````bash\n" +
+		"```\n" +
+		"echo hi"
+
+	assert.Equal(
+		t,
+		[]document.CodeBlock{
+			{Language: "yaml", Content: "a: b\n", Line: 3},
+			{Language: "yml", Content: "c: d\n~~~\n  e: f\n", Line: 8},
+			{Language: "", Content: "", Line: 13},
+			{Language: "bash", Content: "```\necho hi\n", Line: 16},
+		},
+		document.FindCodeBlocks(markdown),
+	)
+}
@@ -0,0 +1,349 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package document
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	headingRegex        = regexp.MustCompile(`^(#{1,6}) (.*)$`)
+	tableSeparatorRegex = regexp.MustCompile(`^\|(\s*:?-+:?\s*\|)+\s*$`)
+	listItemRegex       = regexp.MustCompile(`^(\s*)[-*] (.*)$`)
+	linkRegex           = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]*)\)`)
+	strongRegex         = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	// allowedHTMLRegex matches the HTML tags written by the markdown document and generator, which are the only
+	// tags passed through - anything else is escaped, so untrusted text such as descriptions can't inject scripts.
+	allowedHTMLRegex = regexp.MustCompile(`<(?:/?(?:details|summary)|br ?/?|a name="[^"<>]*"|/a)>`)
+)
+
+// allowedLinkSchemes are the schemes links may use, along with relative links and fragments.
+var allowedLinkSchemes = map[string]bool{"http": true, "https": true}
+
+// MarkdownToHTML renders markdown as HTML. Only the subset of markdown produced by the markdown document is
+// supported - headings, paragraphs, tables, lists, fenced code blocks, raw HTML blocks, links, bold text, inline code
+// and the few HTML tags the markdown document writes.
+func MarkdownToHTML(markdown string) string {
+	r := htmlRenderer{
+		lines:   strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n"),
+		builder: new(strings.Builder),
+		slugger: NewSlugger(),
+	}
+	r.render()
+
+	return r.builder.String()
+}
+
+type htmlRenderer struct {
+	lines   []string
+	pos     int
+	builder *strings.Builder
+	// slugger gives headings the same anchors as GitHub, so links to them work in both.
+	slugger *Slugger
+}
+
+func (r *htmlRenderer) render() {
+	for r.pos < len(r.lines) {
+		line := r.lines[r.pos]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			r.pos++
+		case isFence(line):
+			r.renderCodeBlock()
+		case headingRegex.MatchString(line):
+			r.renderHeading()
+		case r.isTableStart():
+			r.renderTable()
+		case listItemRegex.MatchString(line):
+			r.renderList()
+		case isHTMLBlockStart(line):
+			r.renderRawHTML()
+		default:
+			r.renderParagraph()
+		}
+	}
+}
+
+// isFence returns whether a line opens a fenced code block.
+func isFence(line string) bool {
+	indent, _, _, ok := parseFence(line)
+
+	return ok && indent == ""
+}
+
+func (r *htmlRenderer) renderCodeBlock() {
+	_, fence, format, _ := parseFence(r.lines[r.pos])
+	r.pos++
+
+	var code []string
+
+	// The block is closed by a fence of the same character which is at least as long as the opening fence.
+	for ; r.pos < len(r.lines); r.pos++ {
+		if _, closing, info, ok := parseFence(r.lines[r.pos]); ok && info == "" &&
+			closing[0] == fence[0] && len(closing) >= len(fence) {
+			break
+		}
+
+		code = append(code, r.lines[r.pos])
+	}
+
+	// Skip over the closing marker.
+	r.pos++
+
+	if format != "" {
+		fmt.Fprintf(r.builder, "<pre><code class=\"language-%s\">", html.EscapeString(format))
+	} else {
+		r.builder.WriteString("<pre><code>")
+	}
+
+	r.builder.WriteString(html.EscapeString(strings.Join(code, "\n")))
+	r.builder.WriteString("\n</code></pre>\n")
+}
+
+func (r *htmlRenderer) renderHeading() {
+	match := headingRegex.FindStringSubmatch(r.lines[r.pos])
+	r.pos++
+
+	fmt.Fprintf(
+		r.builder,
+		"<h%d id=\"%s\">%s</h%d>\n",
+		len(match[1]),
+		html.EscapeString(r.slugger.Slug(match[2])),
+		renderInline(match[2]),
+		len(match[1]),
+	)
+}
+
+func (r *htmlRenderer) isTableStart() bool {
+	return strings.HasPrefix(r.lines[r.pos], "|") &&
+		r.pos+1 < len(r.lines) &&
+		tableSeparatorRegex.MatchString(r.lines[r.pos+1])
+}
+
+func (r *htmlRenderer) renderTable() {
+	r.builder.WriteString("<table>\n<thead>\n<tr>")
+
+	for _, cell := range splitTableRow(r.lines[r.pos]) {
+		fmt.Fprintf(r.builder, "<th>%s</th>", renderInline(cell))
+	}
+
+	r.builder.WriteString("</tr>\n</thead>\n<tbody>\n")
+
+	for r.pos += 2; r.pos < len(r.lines) && strings.HasPrefix(r.lines[r.pos], "|"); r.pos++ {
+		r.builder.WriteString("<tr>")
+
+		for _, cell := range splitTableRow(r.lines[r.pos]) {
+			fmt.Fprintf(r.builder, "<td>%s</td>", renderInline(cell))
+		}
+
+		r.builder.WriteString("</tr>\n")
+	}
+
+	r.builder.WriteString("</tbody>\n</table>\n")
+}
+
+// splitTableRow splits a table row into its cells, respecting escaped pipes.
+func splitTableRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+
+	var (
+		cells []string
+		cell  strings.Builder
+	)
+
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func (r *htmlRenderer) renderList() {
+	var indents []int
+
+	for r.pos < len(r.lines) {
+		match := listItemRegex.FindStringSubmatch(r.lines[r.pos])
+		if match == nil {
+			break
+		}
+
+		indent := len(match[1])
+
+		switch {
+		case len(indents) == 0 || indent > indents[len(indents)-1]:
+			r.builder.WriteString("<ul>\n")
+
+			indents = append(indents, indent)
+		default:
+			for len(indents) > 1 && indent < indents[len(indents)-1] {
+				r.builder.WriteString("</li>\n</ul>\n")
+
+				indents = indents[:len(indents)-1]
+			}
+
+			r.builder.WriteString("</li>\n")
+		}
+
+		fmt.Fprintf(r.builder, "<li>%s", renderInline(match[2]))
+		r.pos++
+	}
+
+	for range indents {
+		r.builder.WriteString("</li>\n</ul>\n")
+	}
+}
+
+// isHTMLBlockStart returns whether a line starts with an allowed HTML tag, so starts an HTML block.
+func isHTMLBlockStart(line string) bool {
+	loc := allowedHTMLRegex.FindStringIndex(strings.TrimSpace(line))
+
+	return loc != nil && loc[0] == 0
+}
+
+// renderRawHTML passes lines through until the next blank line, as GitHub does for HTML blocks, escaping anything but
+// the allowed tags.
+func (r *htmlRenderer) renderRawHTML() {
+	for ; r.pos < len(r.lines) && strings.TrimSpace(r.lines[r.pos]) != ""; r.pos++ {
+		r.builder.WriteString(sanitizeHTML(r.lines[r.pos], html.EscapeString))
+		r.builder.WriteString("\n")
+	}
+}
+
+func (r *htmlRenderer) renderParagraph() {
+	var text []string
+
+	for ; r.pos < len(r.lines); r.pos++ {
+		line := r.lines[r.pos]
+
+		if strings.TrimSpace(line) == "" ||
+			isFence(line) ||
+			headingRegex.MatchString(line) ||
+			r.isTableStart() ||
+			listItemRegex.MatchString(line) {
+			break
+		}
+
+		text = append(text, renderInline(line))
+	}
+
+	fmt.Fprintf(r.builder, "<p>%s</p>\n", strings.Join(text, "\n"))
+}
+
+// renderInline renders inline code spans, links and bold text, escaping everything else.
+func renderInline(text string) string {
+	var builder strings.Builder
+
+	segments := strings.Split(text, "`")
+
+	for idx, segment := range segments {
+		switch {
+		case idx%2 == 1 && idx != len(segments)-1:
+			fmt.Fprintf(&builder, "<code>%s</code>", html.EscapeString(segment))
+		case idx%2 == 1:
+			// Unmatched backtick, so render it literally.
+			builder.WriteString("`" + renderLinks(segment))
+		default:
+			builder.WriteString(renderLinks(segment))
+		}
+	}
+
+	return builder.String()
+}
+
+func renderLinks(text string) string {
+	var builder strings.Builder
+
+	last := 0
+
+	for _, match := range linkRegex.FindAllStringSubmatchIndex(text, -1) {
+		builder.WriteString(renderStrong(text[last:match[0]]))
+
+		target := text[match[4]:match[5]]
+		if isAllowedLink(target) {
+			fmt.Fprintf(
+				&builder,
+				"<a href=\"%s\">%s</a>",
+				html.EscapeString(target),
+				renderStrong(text[match[2]:match[3]]),
+			)
+		} else {
+			// Render links with other schemes, such as javascript:, as their text.
+			builder.WriteString(renderStrong(text[match[2]:match[3]]))
+		}
+
+		last = match[1]
+	}
+
+	builder.WriteString(renderStrong(text[last:]))
+
+	return builder.String()
+}
+
+// isAllowedLink returns whether a link target is an http(s) URL, a relative URL or a fragment.
+func isAllowedLink(target string) bool {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+
+	return parsed.Scheme == "" || allowedLinkSchemes[parsed.Scheme]
+}
+
+func renderStrong(text string) string {
+	return sanitizeHTML(text, func(text string) string {
+		return strongRegex.ReplaceAllString(html.EscapeString(text), "<strong>$1</strong>")
+	})
+}
+
+// sanitizeHTML passes through the allowed HTML tags in text, rendering the text between them with render.
+func sanitizeHTML(text string, render func(string) string) string {
+	var builder strings.Builder
+
+	last := 0
+
+	for _, match := range allowedHTMLRegex.FindAllStringIndex(text, -1) {
+		builder.WriteString(render(text[last:match[0]]))
+		builder.WriteString(text[match[0]:match[1]])
+
+		last = match[1]
+	}
+
+	builder.WriteString(render(text[last:]))
+
+	return builder.String()
+}
@@ -0,0 +1,60 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package document
+
+import (
+	_ "embed"
+	"fmt"
+	"html"
+	"strings"
+)
+
+//go:embed static/style.css
+var stylesheet string
+
+// HTMLPage is a standalone HTML page styled to look like GitHub's rendering of markdown.
+type HTMLPage struct {
+	Title string
+	// Body is the HTML content of the page.
+	Body string
+	// Scripts are javascript snippets included at the end of the page.
+	Scripts []string
+}
+
+func (p HTMLPage) Render() string {
+	builder := new(strings.Builder)
+
+	builder.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	builder.WriteString("<meta name=\"viewport\" content=\"width=device-width, initial-scale=1\">\n")
+	fmt.Fprintf(builder, "<title>%s</title>\n", html.EscapeString(p.Title))
+	fmt.Fprintf(builder, "<style>\n%s</style>\n", stylesheet)
+	builder.WriteString("</head>\n<body>\n")
+	builder.WriteString(p.Body)
+
+	for _, script := range p.Scripts {
+		fmt.Fprintf(builder, "<script>\n%s\n</script>\n", script)
+	}
+
+	builder.WriteString("</body>\n</html>\n")
+
+	return builder.String()
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package document_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/document"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{"heading", "## Inputs\n", "<h2 id=\"inputs\">Inputs</h2>\n"},
+		{
+			"duplicate headings",
+			"# Inputs\n## Inputs\n### `a` input\n",
+			"<h1 id=\"inputs\">Inputs</h1>\n<h2 id=\"inputs-1\">Inputs</h2>\n<h3 id=\"a-input\"><code>a</code> input</h3>\n",
+		},
+		{"paragraph", "some\ntext\n\nmore", "<p>some\ntext</p>\n<p>more</p>\n"},
+		{"escaping", "a < b & c", "<p>a &lt; b &amp; c</p>\n"},
+		{
+			"inline",
+			"see [docs](https://example.com), `x < y` and **bold**",
+			"<p>see <a href=\"https://example.com\">docs</a>, <code>x &lt; y</code> and <strong>bold</strong></p>\n",
+		},
+		{
+			"inline_html",
+			"see <a name=\"input-a\"></a>a<br>b <script>",
+			"<p>see <a name=\"input-a\"></a>a<br>b &lt;script&gt;</p>\n",
+		},
+		{
+			"inline_html_attributes",
+			"<a href=\"javascript:alert(1)\" onclick=\"alert(1)\">x</a> <a name=\"a\" onclick=\"alert(1)\"></a>",
+			"<p>&lt;a href=&#34;javascript:alert(1)&#34; onclick=&#34;alert(1)&#34;&gt;x</a> " +
+				"&lt;a name=&#34;a&#34; onclick=&#34;alert(1)&#34;&gt;</a></p>\n",
+		},
+		{
+			"inline_html_unknown_tags",
+			"<em>a</em> <img src=x onerror=alert(1)>",
+			"<p>&lt;em&gt;a&lt;/em&gt; &lt;img src=x onerror=alert(1)&gt;</p>\n",
+		},
+		{
+			"script_block",
+			"<script>alert(1)</script>\n",
+			"<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n",
+		},
+		{
+			"raw_html_escaping",
+			"<details>\n<summary><script>alert(1)</script></summary>\n</details>\n",
+			"<details>\n<summary>&lt;script&gt;alert(1)&lt;/script&gt;</summary>\n</details>\n",
+		},
+		{
+			"link_schemes",
+			"[a](javascript:alert%281%29) [b](JavaScript:alert) [c](data:text/html,x) [d](#input-a) [e](docs/a.md) " +
+				"[f](http://example.com)",
+			"<p>a b c <a href=\"#input-a\">d</a> <a href=\"docs/a.md\">e</a> " +
+				"<a href=\"http://example.com\">f</a></p>\n",
+		},
+		{
+			"code_block",
+			"```yaml\n- name: <test>\n```\n",
+			"<pre><code class=\"language-yaml\">- name: &lt;test&gt;\n</code></pre>\n",
+		},
+		{
+			"nested_fence",
+			"````sh\ncat <<'MD'\n```\ndone\n```\nMD\n````\nafter\n",
+			"<pre><code class=\"language-sh\">cat &lt;&lt;&#39;MD&#39;\n```\ndone\n```\nMD\n</code></pre>\n<p>after</p>\n",
+		},
+		{
+			"table",
+			"| Name | Value |\n| --- | --- |\n| a | `a\\|b` |\n",
+			"<table>\n<thead>\n<tr><th>Name</th><th>Value</th></tr>\n</thead>\n<tbody>\n" +
+				"<tr><td>a</td><td><code>a|b</code></td></tr>\n</tbody>\n</table>\n",
+		},
+		{
+			"nested_list",
+			"- a\n  - b\n- c\n",
+			"<ul>\n<li>a<ul>\n<li>b</li>\n</ul>\n</li>\n<li>c</li>\n</ul>\n",
+		},
+		{
+			"raw_html",
+			"<details>\n<summary>Script</summary>\n\n```sh\necho\n```\n</details>\n",
+			"<details>\n<summary>Script</summary>\n<pre><code class=\"language-sh\">echo\n</code></pre>\n</details>\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, document.MarkdownToHTML(tc.markdown), tc.name)
+	}
+}
+
+func TestHTMLPageRender(t *testing.T) {
+	t.Parallel()
+
+	page := document.HTMLPage{Title: "a & b", Body: "<p>body</p>\n", Scripts: []string{"console.log(1);"}}
+	rendered := page.Render()
+
+	assert.Contains(t, rendered, "<title>a &amp; b</title>")
+	assert.Contains(t, rendered, "<style>")
+	assert.Contains(t, rendered, "<body>\n<p>body</p>\n<script>\nconsole.log(1);\n</script>\n</body>")
+}
@@ -29,7 +29,18 @@ import (
 )
 
 type MarkdownDocument struct {
-	builder *strings.Builder
+	builder       *strings.Builder
+	slugger       *Slugger
+	headings      []Heading
+	headingOffset int
+}
+
+// Heading is a heading written to a markdown document.
+type Heading struct {
+	Text  string
+	Level MarkdownHeadingLevel
+	// Anchor is the anchor GitHub gives the heading, which is unique within the document.
+	Anchor string
 }
 
 // NewMarkdownAction returns a new markdown action that wraps the provided composite action data structure,
@@ -37,10 +48,39 @@ type MarkdownDocument struct {
 func NewMarkdownDocument() *MarkdownDocument {
 	mda := new(MarkdownDocument)
 	mda.builder = new(strings.Builder)
+	mda.slugger = NewSlugger()
 
 	return mda
 }
 
+// NewSection returns an empty document for content which will be written to this document later with
+// WriteDocument. Headings are given anchors unique across both documents, so the section's headings can be linked
+// to before its content is written, e.g. from a table of contents.
+func (m *MarkdownDocument) NewSection() *MarkdownDocument {
+	return &MarkdownDocument{builder: new(strings.Builder), slugger: m.slugger, headingOffset: m.headingOffset}
+}
+
+// SetHeadingOffset shifts the level of headings written to the document, and to sections created from it, by the
+// given number of levels, e.g. so an H1 is written as an H2 when embedding the document in a larger one. Headings are
+// never written deeper than H6.
+func (m *MarkdownDocument) SetHeadingOffset(offset int) *MarkdownDocument {
+	m.headingOffset = offset
+	return m
+}
+
+// WriteDocument writes the content of another document, such as a section, to this document.
+func (m *MarkdownDocument) WriteDocument(other *MarkdownDocument) *MarkdownDocument {
+	m.WriteText(other.Render())
+	m.headings = append(m.headings, other.headings...)
+
+	return m
+}
+
+// Headings returns the headings written to the document, in order.
+func (m MarkdownDocument) Headings() []Heading {
+	return m.headings
+}
+
 func (m MarkdownDocument) Render() string {
 	return m.builder.String()
 }
@@ -78,10 +118,38 @@ func (mdh MarkdownHeadingLevel) Value() int {
 }
 
 func (m *MarkdownDocument) WriteHeading(text string, level MarkdownHeadingLevel) *MarkdownDocument {
+	level += MarkdownHeadingLevel(m.headingOffset)
+	if level > H6 {
+		level = H6
+	}
+
 	heading := fmt.Sprintf("%s %s", strings.Repeat("#", level.Value()), text)
 	m.WriteText(heading)
 	m.WriteNewLine()
 
+	m.headings = append(m.headings, Heading{Text: text, Level: level, Anchor: m.slugger.Slug(text)})
+
+	return m
+}
+
+// WriteTableOfContents writes a list of links to the given headings, nested by their level.
+func (m *MarkdownDocument) WriteTableOfContents(headings []Heading) *MarkdownDocument {
+	if len(headings) == 0 {
+		return m
+	}
+
+	top := headings[0].Level
+	for _, heading := range headings {
+		if heading.Level < top {
+			top = heading.Level
+		}
+	}
+
+	for _, heading := range headings {
+		indent := strings.Repeat("  ", int(heading.Level-top))
+		m.WriteTextLn(fmt.Sprintf("%s- %s", indent, m.CreateLink(heading.Text, "#"+heading.Anchor)))
+	}
+
 	return m
 }
 
@@ -89,7 +157,7 @@ func (m *MarkdownDocument) writeTableHeader(columns []string) *MarkdownDocument
 	m.WriteText("|")
 
 	for _, column := range columns {
-		m.WriteText(fmt.Sprintf(" %s |", column))
+		m.WriteText(fmt.Sprintf(" %s |", escapeTableCell(column)))
 	}
 
 	m.WriteNewLine()
@@ -107,7 +175,7 @@ func (m *MarkdownDocument) writeTableRows(rows [][]string) *MarkdownDocument {
 		m.WriteText("|")
 
 		for _, value := range row {
-			m.WriteText(fmt.Sprintf(" %s |", value))
+			m.WriteText(fmt.Sprintf(" %s |", escapeTableCell(value)))
 		}
 
 		m.WriteNewLine()
@@ -116,6 +184,13 @@ func (m *MarkdownDocument) writeTableRows(rows [][]string) *MarkdownDocument {
 	return m
 }
 
+// escapeTableCell escapes the pipes in the content of a table cell, which would otherwise end the cell, even inside
+// code spans.
+func escapeTableCell(value string) string {
+	return strings.ReplaceAll(value, "|", "\\|")
+}
+
+// WriteTable writes a table with the given column headers and rows. Pipes in headers and cells are escaped.
 func (m *MarkdownDocument) WriteTable(columns []string, rows [][]string) (*MarkdownDocument, error) {
 	for _, row := range rows {
 		if len(row) != len(columns) {
@@ -134,6 +209,11 @@ func (m MarkdownDocument) CreateLink(title, url string) string {
 	return fmt.Sprintf("[%s](%s)", title, url)
 }
 
+// CreateAnchor returns an inline HTML anchor, which can be linked to with "#name".
+func (m MarkdownDocument) CreateAnchor(name string) string {
+	return fmt.Sprintf("<a name=\"%s\"></a>", name)
+}
+
 func (m MarkdownDocument) FormatCode(text string) string {
 	if text == "" {
 		return text
@@ -157,3 +237,18 @@ func (m *MarkdownDocument) WriteCodeBlockMarkerWithFormat(format string) *Markdo
 
 	return m
 }
+
+// WriteCodeBlock writes code in a fenced code block. The fence is made longer than any run of backticks in the code,
+// so code containing fences of its own, such as a script writing markdown, can't close the block early.
+func (m *MarkdownDocument) WriteCodeBlock(format, code string) *MarkdownDocument {
+	fence := CodeBlockMarker
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+
+	m.WriteTextLn(fence + format)
+	m.WriteTextLn(strings.TrimRight(code, "\n"))
+	m.WriteTextLn(fence)
+
+	return m
+}
@@ -90,6 +90,21 @@ func TestMarkdownWriteHeading(t *testing.T) {
 	}
 }
 
+func TestMarkdownWriteHeadingOffset(t *testing.T) {
+	t.Parallel()
+
+	doc := document.NewMarkdownDocument().SetHeadingOffset(1)
+	doc.WriteHeading("Title", document.H1)
+
+	section := doc.NewSection()
+	section.WriteHeading("Section", document.H2)
+	section.WriteHeading("Deep", document.H6)
+	doc.WriteDocument(section)
+
+	assert.Equal(t, "## Title\n### Section\n###### Deep\n", doc.Render())
+	assert.Equal(t, document.H2, doc.Headings()[0].Level)
+}
+
 func TestMarkdownWriteTable(t *testing.T) {
 	t.Parallel()
 
@@ -109,6 +124,13 @@ func TestMarkdownWriteTable(t *testing.T) {
 			"| two | columns |\n| --- | --- |\n| and | some |\n| more | rows |\n",
 			false,
 		},
+		{
+			"escaped_pipes",
+			[]string{"a|b"},
+			[][]string{{"`x || y`"}},
+			"| a\\|b |\n| --- |\n| `x \\|\\| y` |\n",
+			false,
+		},
 		{
 			"single_column_multi_row_error",
 			[]string{"main"},
@@ -168,6 +190,12 @@ func TestCreateMarkdownLink(t *testing.T) {
 	}
 }
 
+func TestCreateMarkdownAnchor(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `<a name="input-a"></a>`, document.NewMarkdownDocument().CreateAnchor("input-a"))
+}
+
 func TestMarkdownFormatCode(t *testing.T) {
 	t.Parallel()
 
@@ -229,3 +257,60 @@ func TestMarkdownWriteCodeBlockMarkerFormat(t *testing.T) {
 		assert.Equal(t, tc.expected, doc.Render())
 	}
 }
+
+func TestMarkdownWriteCodeBlock(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		code     string
+		expected string
+	}{
+		{"plain", "echo hi\n", "```sh\necho hi\n```\n"},
+		{"inline_backticks", "echo `date`", "```sh\necho `date`\n```\n"},
+		{"fence", "cat <<'MD'\n```\ndone\n```\nMD\n", "````sh\ncat <<'MD'\n```\ndone\n```\nMD\n````\n"},
+		{"long_fence", "echo '`````'", "``````sh\necho '`````'\n``````\n"},
+	}
+
+	for _, tc := range testCases {
+		doc := document.NewMarkdownDocument()
+		doc.WriteCodeBlock("sh", tc.code)
+		assert.Equal(t, tc.expected, doc.Render(), tc.name)
+	}
+}
+
+func TestWriteTableOfContents(t *testing.T) {
+	t.Parallel()
+
+	doc := document.NewMarkdownDocument()
+	doc.WriteHeading("Title", document.H1)
+
+	section := doc.NewSection()
+	section.WriteHeading("Title", document.H2)
+	section.WriteHeading("`Sub` heading", document.H3)
+	section.WriteHeading("Other", document.H2)
+
+	doc.WriteTableOfContents(section.Headings())
+	doc.WriteDocument(section)
+
+	expected := `# Title
+- [Title](#title-1)
+  - [` + "`Sub`" + ` heading](#sub-heading)
+- [Other](#other)
+## Title
+### ` + "`Sub`" + ` heading
+## Other
+`
+
+	assert.Equal(t, expected, doc.Render())
+	assert.Equal(
+		t,
+		[]document.Heading{
+			{Text: "Title", Level: document.H1, Anchor: "title"},
+			{Text: "Title", Level: document.H2, Anchor: "title-1"},
+			{Text: "`Sub` heading", Level: document.H3, Anchor: "sub-heading"},
+			{Text: "Other", Level: document.H2, Anchor: "other"},
+		},
+		doc.Headings(),
+	)
+}
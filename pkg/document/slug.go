@@ -0,0 +1,85 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package document
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	htmlTagRegex  = regexp.MustCompile(`<[^>]+>`)
+	linkTextRegex = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+)
+
+// Slugger creates the anchors GitHub gives headings, following the same algorithm so that links to them work when
+// the markdown is rendered on GitHub. Anchors are unique within a slugger, with duplicates given a numbered suffix.
+type Slugger struct {
+	occurrences map[string]int
+}
+
+// NewSlugger returns a slugger which hasn't created any anchors.
+func NewSlugger() *Slugger {
+	return &Slugger{occurrences: map[string]int{}}
+}
+
+// Slug returns the anchor for a heading, given as markdown. If the anchor has already been created, a suffix of -1,
+// -2 and so on is added.
+func (s *Slugger) Slug(heading string) string {
+	original := Slug(heading)
+	slug := original
+
+	for {
+		if _, ok := s.occurrences[slug]; !ok {
+			break
+		}
+
+		s.occurrences[original]++
+		slug = fmt.Sprintf("%s-%d", original, s.occurrences[original])
+	}
+
+	s.occurrences[slug] = 0
+
+	return slug
+}
+
+// Slug returns the anchor GitHub gives a heading, given as markdown, ignoring any duplicates. The text of the heading
+// is lower cased, stripped of everything other than letters, numbers, marks, spaces, dashes and underscores, and has
+// its spaces replaced with dashes.
+func Slug(heading string) string {
+	text := htmlTagRegex.ReplaceAllString(heading, "")
+	text = linkTextRegex.ReplaceAllString(text, "$1")
+	text = strings.NewReplacer("`", "", "**", "").Replace(text)
+
+	return strings.Map(func(char rune) rune {
+		switch {
+		case char == ' ':
+			return '-'
+		case char == '-' || char == '_' || unicode.IsLetter(char) || unicode.IsNumber(char) || unicode.IsMark(char):
+			return char
+		default:
+			return -1
+		}
+	}, strings.ToLower(strings.TrimSpace(text)))
+}
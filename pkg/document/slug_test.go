@@ -0,0 +1,63 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package document_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/document"
+)
+
+func TestSlug(t *testing.T) {
+	t.Parallel()
+
+	for heading, expected := range map[string]string{
+		"Example Usage":                   "example-usage",
+		"1. Run echo hi":                  "1-run-echo-hi",
+		"What's new?":                     "whats-new",
+		"C++ & Go":                        "c--go",
+		"`code` heading":                  "code-heading",
+		"**Bold** heading":                "bold-heading",
+		"[A link](https://example.com) x": "a-link-x",
+		"<a name=\"x\"></a>Anchored":      "anchored",
+		"Ünïcödé Heading":                 "ünïcödé-heading",
+		"snake_case-name":                 "snake_case-name",
+		"🚀 Launch":                        "-launch",
+	} {
+		assert.Equal(t, expected, document.Slug(heading), heading)
+	}
+}
+
+func TestSluggerDuplicates(t *testing.T) {
+	t.Parallel()
+
+	slugger := document.NewSlugger()
+
+	var slugs []string
+	for _, heading := range []string{"Inputs", "Inputs", "Inputs 1", "Inputs", "Outputs"} {
+		slugs = append(slugs, slugger.Slug(heading))
+	}
+
+	assert.Equal(t, []string{"inputs", "inputs-1", "inputs-1-1", "inputs-2", "outputs"}, slugs)
+}
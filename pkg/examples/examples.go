@@ -0,0 +1,123 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package examples
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/matty-rose/gha-docs/pkg/resolver"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+// Step is a step in an example which uses an action.
+type Step struct {
+	Name string            `yaml:"name"`
+	ID   string            `yaml:"id"`
+	Uses string            `yaml:"uses"`
+	With map[string]string `yaml:"with"`
+	// Line is the line the step starts on in the example.
+	Line int `yaml:"-"`
+}
+
+// ParseSteps parses an example, which can be a single step, a list of steps, a job or a complete workflow, returning
+// every step in it which uses an action.
+func ParseSteps(content []byte) ([]Step, error) {
+	var root yaml.Node
+
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, errors.Wrap(err, "couldn't parse the example")
+	}
+
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+
+	var steps []Step
+
+	for _, node := range findSteps(root.Content[0]) {
+		var step Step
+
+		if err := node.Decode(&step); err != nil {
+			return nil, errors.Wrapf(err, "couldn't parse the step on line %d", node.Line)
+		}
+
+		if step.Uses != "" {
+			step.Line = node.Line
+			steps = append(steps, step)
+		}
+	}
+
+	return steps, nil
+}
+
+// findSteps returns the nodes of every step in an example.
+func findSteps(node *yaml.Node) []*yaml.Node {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var steps []*yaml.Node
+
+		for _, item := range node.Content {
+			if item.Kind == yaml.MappingNode {
+				steps = append(steps, item)
+			}
+		}
+
+		return steps
+	case yaml.MappingNode:
+		if jobs := mappingValue(node, "jobs"); jobs != nil && jobs.Kind == yaml.MappingNode {
+			var steps []*yaml.Node
+
+			// Mapping nodes hold their keys and values alternately.
+			for idx := 1; idx < len(jobs.Content); idx += 2 {
+				steps = append(steps, findSteps(jobs.Content[idx])...)
+			}
+
+			return steps
+		}
+
+		if steps := mappingValue(node, "steps"); steps != nil {
+			return findSteps(steps)
+		}
+
+		return []*yaml.Node{node}
+	default:
+		return nil
+	}
+}
+
+// mappingValue returns the value of a key in a mapping node, or nil if it isn't set.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		if node.Content[idx].Value == key {
+			return node.Content[idx+1]
+		}
+	}
+
+	return nil
+}
+
+// CheckInputs returns the problems with the inputs a step passes to an action, such as unknown inputs or missing
+// required inputs.
+func CheckInputs(action *types.CompositeAction, step Step) []string {
+	return resolver.Validate(types.ExternalAction{With: step.With, Resolved: action})
+}
@@ -0,0 +1,144 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package examples_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/examples"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+func TestParseStepsList(t *testing.T) {
+	t.Parallel()
+
+	steps, err := examples.ParseSteps([]byte(`- uses: actions/checkout@v2
+- run: echo hello
+- name: Build
+  id: build
+  uses: owner/repo@v1
+  with:
+    path: ./src
+    debug: true
+    token:
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(
+		t,
+		[]examples.Step{
+			{Uses: "actions/checkout@v2", Line: 1},
+			{
+				Name: "Build",
+				ID:   "build",
+				Uses: "owner/repo@v1",
+				With: map[string]string{"path": "./src", "debug": "true", "token": ""},
+				Line: 3,
+			},
+		},
+		steps,
+	)
+}
+
+func TestParseStepsSingle(t *testing.T) {
+	t.Parallel()
+
+	steps, err := examples.ParseSteps([]byte("uses: owner/repo@v1\nwith:\n  a: b\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []examples.Step{{Uses: "owner/repo@v1", With: map[string]string{"a": "b"}, Line: 1}}, steps)
+}
+
+func TestParseStepsWorkflow(t *testing.T) {
+	t.Parallel()
+
+	steps, err := examples.ParseSteps([]byte(`on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v2
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: owner/repo@v1
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(
+		t,
+		[]examples.Step{{Uses: "actions/checkout@v2", Line: 6}, {Uses: "owner/repo@v1", Line: 10}},
+		steps,
+	)
+}
+
+func TestParseStepsJob(t *testing.T) {
+	t.Parallel()
+
+	steps, err := examples.ParseSteps([]byte("runs-on: ubuntu-latest\nsteps:\n  - uses: owner/repo@v1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []examples.Step{{Uses: "owner/repo@v1", Line: 3}}, steps)
+}
+
+func TestParseStepsInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := examples.ParseSteps([]byte("- uses: [unterminated\n"))
+	assert.Error(t, err)
+
+	_, err = examples.ParseSteps([]byte("- uses: owner/repo@v1\n  with: [a, b]\n"))
+	assert.EqualError(
+		t,
+		err,
+		"couldn't parse the step on line 1: yaml: unmarshal errors:\n  line 2: cannot unmarshal !!seq into map[string]string",
+	)
+
+	steps, err := examples.ParseSteps([]byte(""))
+	assert.NoError(t, err)
+	assert.Empty(t, steps)
+}
+
+func TestCheckInputs(t *testing.T) {
+	t.Parallel()
+
+	action := types.CompositeAction{
+		Inputs: []types.Input{{Name: "path", Required: true}, {Name: "debug"}},
+	}
+
+	assert.Empty(t, examples.CheckInputs(&action, examples.Step{With: map[string]string{"path": "."}}))
+	assert.Equal(
+		t,
+		[]string{"missing required input path", "unknown input extra"},
+		examples.CheckInputs(&action, examples.Step{With: map[string]string{"debug": "true", "extra": "x"}}),
+	)
+}
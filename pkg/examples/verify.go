@@ -0,0 +1,173 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package examples
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/matty-rose/gha-docs/pkg/document"
+	"github.com/matty-rose/gha-docs/pkg/expression"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+// Problem is a problem with an example in a markdown document.
+type Problem struct {
+	// Line is the line of the document the problem is on.
+	Line    int
+	Message string
+}
+
+// UsesMatcher returns a function reporting whether a uses reference is to one of the given references, ignoring the
+// ref after the @. References are compared case insensitively, as GitHub owner and repository names are.
+func UsesMatcher(references ...string) func(uses string) bool {
+	return func(uses string) bool {
+		uses = strings.SplitN(uses, "@", 2)[0]
+
+		for _, reference := range references {
+			if strings.EqualFold(uses, strings.SplitN(reference, "@", 2)[0]) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Report is the result of verifying the examples in a markdown document.
+type Report struct {
+	// Steps is the number of steps found using the action, including those in blocks which couldn't be parsed. If it
+	// is zero, nothing was checked.
+	Steps    int
+	Problems []Problem
+}
+
+// Verify checks every YAML code block in a markdown document with a step using the action, as decided by matches,
+// reporting the problems found in line order. Unknown inputs, missing required inputs and references to unknown
+// outputs of the steps using the action are reported.
+func Verify(markdown string, action *types.CompositeAction, matches func(uses string) bool) Report {
+	var report Report
+
+	for _, block := range document.FindCodeBlocks(markdown) {
+		if block.Language != "yaml" && block.Language != "yml" {
+			continue
+		}
+
+		steps, problems := verifyBlock(block, action, matches)
+		report.Steps += steps
+		report.Problems = append(report.Problems, problems...)
+	}
+
+	return report
+}
+
+// verifyBlock checks the steps using the action in a code block, returning the number of them and the problems found.
+func verifyBlock(
+	block document.CodeBlock,
+	action *types.CompositeAction,
+	matches func(uses string) bool,
+) (int, []Problem) {
+	steps, err := ParseSteps([]byte(block.Content))
+	if err != nil {
+		// Blocks which aren't valid YAML are only a problem if they look like they use the action.
+		if mentionsAction(block.Content, matches) {
+			return 1, []Problem{{Line: block.Line, Message: err.Error()}}
+		}
+
+		return 0, nil
+	}
+
+	var (
+		problems []Problem
+		count    int
+	)
+
+	ids := map[string]bool{}
+
+	for _, step := range steps {
+		if !matches(step.Uses) {
+			continue
+		}
+
+		count++
+
+		if step.ID != "" {
+			ids[step.ID] = true
+		}
+
+		for _, message := range CheckInputs(action, step) {
+			problems = append(problems, Problem{Line: block.Line + step.Line - 1, Message: message})
+		}
+	}
+
+	problems = append(problems, verifyOutputReferences(block, action, ids)...)
+
+	sort.SliceStable(problems, func(a, b int) bool {
+		return problems[a].Line < problems[b].Line
+	})
+
+	return count, problems
+}
+
+// verifyOutputReferences returns a problem for each reference to an output of a step using the action which the
+// action doesn't declare.
+func verifyOutputReferences(block document.CodeBlock, action *types.CompositeAction, ids map[string]bool) []Problem {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	outputs := make(map[string]bool, len(action.Outputs))
+	for _, out := range action.Outputs {
+		outputs[out.Name] = true
+	}
+
+	var problems []Problem
+
+	for _, expr := range expression.FindAll(block.Content) {
+		for _, ref := range expr.References() {
+			if ref.Context != "steps" || len(ref.Path) < 3 || !ids[ref.Path[0]] || ref.Path[1] != "outputs" {
+				continue
+			}
+
+			if name := ref.Path[2]; !outputs[name] {
+				line := block.Line + strings.Count(block.Content[:expr.SourceOffset(ref.Pos)], "\n")
+				problems = append(problems, Problem{Line: line, Message: fmt.Sprintf("unknown output %s", name)})
+			}
+		}
+	}
+
+	return problems
+}
+
+// mentionsAction reports whether any uses key in some text refers to the action.
+func mentionsAction(text string, matches func(uses string) bool) bool {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimLeft(strings.TrimSpace(line), "- ")
+		uses := strings.TrimPrefix(line, "uses:")
+		if uses != line && matches(strings.Trim(strings.TrimSpace(uses), `"'`)) {
+			return true
+		}
+	}
+
+	return false
+}
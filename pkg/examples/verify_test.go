@@ -0,0 +1,119 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package examples_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/examples"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+func newVerifyAction() *types.CompositeAction {
+	return &types.CompositeAction{
+		Inputs:  []types.Input{{Name: "path", Required: true}, {Name: "debug"}},
+		Outputs: []types.Output{{Name: "artifact"}},
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	markdown := "# Usage\n" +
+		"```yaml\n" +
+		"- uses: actions/checkout@v2\n" +
+		"  with:\n" +
+		"    unrelated: input\n" +
+		"- id: build\n" +
+		"  uses: Owner/Repo@v1\n" +
+		"  with:\n" +
+		"    debug: true\n" +
+		"    extra: x\n" +
+		"- run: echo ${{ steps.build.outputs.artifact }} ${{ steps.build.outputs.missing }}\n" +
+		"  env:\n" +
+		"    OTHER: ${{ steps.other.outputs.missing }}\n" +
+		"```\n" +
+		"\n" +
+		"```bash\n" +
+		"uses: owner/repo@v1\n" +
+		"```\n" +
+		"\n" +
+		"```yml\n" +
+		"on: push\n" +
+		"jobs:\n" +
+		"  build:\n" +
+		"    steps:\n" +
+		"      - uses: owner/repo/sub@v1\n" +
+		"      - uses: owner/repo@main\n" +
+		"        with:\n" +
+		"          path: .\n" +
+		"```\n"
+
+	assert.Equal(
+		t,
+		examples.Report{
+			Steps: 2,
+			Problems: []examples.Problem{
+				{Line: 6, Message: "missing required input path"},
+				{Line: 6, Message: "unknown input extra"},
+				{Line: 11, Message: "unknown output missing"},
+			},
+		},
+		examples.Verify(markdown, newVerifyAction(), examples.UsesMatcher("owner/repo")),
+	)
+
+	report := examples.Verify(markdown, newVerifyAction(), examples.UsesMatcher("my-org/my-action"))
+	assert.Zero(t, report.Steps)
+	assert.Empty(t, report.Problems)
+}
+
+func TestVerifyInvalidYAML(t *testing.T) {
+	t.Parallel()
+
+	markdown := "```yaml\n" +
+		"- uses: owner/repo@v1\n" +
+		"  with: [\n" +
+		"```\n" +
+		"```yaml\n" +
+		"not: [valid\n" +
+		"```\n"
+
+	problems := examples.Verify(markdown, newVerifyAction(), examples.UsesMatcher("owner/repo")).Problems
+	if assert.Len(t, problems, 1) {
+		assert.Equal(t, 2, problems[0].Line)
+		assert.Contains(t, problems[0].Message, "couldn't parse the example")
+	}
+}
+
+func TestUsesMatcher(t *testing.T) {
+	t.Parallel()
+
+	matches := examples.UsesMatcher("owner/repo", "./path/to/action.yml@ignored")
+
+	assert.True(t, matches("owner/repo@v1"))
+	assert.True(t, matches("OWNER/Repo"))
+	assert.True(t, matches("./path/to/action.yml"))
+	assert.False(t, matches("owner/repo/sub@v1"))
+	assert.False(t, matches("owner/other@v1"))
+}
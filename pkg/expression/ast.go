@@ -0,0 +1,174 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package expression
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Node is a node of the syntax tree of an expression.
+type Node interface {
+	// Position returns the byte offset of the start of the node in the expression.
+	Position() int
+	// String returns the node formatted as expression source.
+	String() string
+}
+
+// LiteralKind is the type of a literal value.
+type LiteralKind int
+
+const (
+	NullLiteral LiteralKind = iota
+	BooleanLiteral
+	NumberLiteral
+	StringLiteral
+)
+
+// Literal is a null, boolean, number or string value.
+type Literal struct {
+	Pos  int
+	Kind LiteralKind
+	// Value is the literal as written for null, booleans and numbers, and the unquoted value for strings.
+	Value string
+}
+
+func (l *Literal) Position() int {
+	return l.Pos
+}
+
+func (l *Literal) String() string {
+	if l.Kind == StringLiteral {
+		return fmt.Sprintf("'%s'", strings.ReplaceAll(l.Value, "'", "''"))
+	}
+
+	return l.Value
+}
+
+// Context is a reference to a context by name, such as github or inputs.
+type Context struct {
+	Pos  int
+	Name string
+}
+
+func (c *Context) Position() int {
+	return c.Pos
+}
+
+func (c *Context) String() string {
+	return c.Name
+}
+
+// PropertyAccess accesses a property of an object with dot syntax, e.g. github.sha. The property is * for object
+// filters, e.g. steps.*.outcome.
+type PropertyAccess struct {
+	Pos      int
+	Object   Node
+	Property string
+}
+
+func (p *PropertyAccess) Position() int {
+	return p.Pos
+}
+
+func (p *PropertyAccess) String() string {
+	return fmt.Sprintf("%s.%s", p.Object, p.Property)
+}
+
+// IndexAccess accesses a property or element of an object or array with index syntax, e.g. inputs['name'].
+type IndexAccess struct {
+	Pos    int
+	Object Node
+	Index  Node
+}
+
+func (i *IndexAccess) Position() int {
+	return i.Pos
+}
+
+func (i *IndexAccess) String() string {
+	return fmt.Sprintf("%s[%s]", i.Object, i.Index)
+}
+
+// FunctionCall calls a function, e.g. format('{0}', github.sha).
+type FunctionCall struct {
+	Pos       int
+	Name      string
+	Arguments []Node
+}
+
+func (f *FunctionCall) Position() int {
+	return f.Pos
+}
+
+func (f *FunctionCall) String() string {
+	args := make([]string, 0, len(f.Arguments))
+	for _, arg := range f.Arguments {
+		args = append(args, arg.String())
+	}
+
+	return fmt.Sprintf("%s(%s)", f.Name, strings.Join(args, ", "))
+}
+
+// UnaryOperation applies the ! operator to its operand.
+type UnaryOperation struct {
+	Pos      int
+	Operator TokenKind
+	Operand  Node
+}
+
+func (u *UnaryOperation) Position() int {
+	return u.Pos
+}
+
+func (u *UnaryOperation) String() string {
+	return fmt.Sprintf("!%s", formatOperand(u.Operand))
+}
+
+// BinaryOperation applies a comparison or logical operator to its operands.
+type BinaryOperation struct {
+	Pos      int
+	Operator TokenKind
+	Left     Node
+	Right    Node
+}
+
+func (b *BinaryOperation) Position() int {
+	return b.Pos
+}
+
+func (b *BinaryOperation) String() string {
+	return fmt.Sprintf("%s %s %s", formatOperand(b.Left), operatorText(b.Operator), formatOperand(b.Right))
+}
+
+// formatOperand formats an operand, wrapping operations in parentheses so that precedence is preserved.
+func formatOperand(node Node) string {
+	if _, ok := node.(*BinaryOperation); ok {
+		return fmt.Sprintf("(%s)", node)
+	}
+
+	return node.String()
+}
+
+func operatorText(kind TokenKind) string {
+	return strings.Trim(kind.String(), "'")
+}
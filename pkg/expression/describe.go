@@ -0,0 +1,137 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package expression
+
+import (
+	// Used to embed the table of known contexts.
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed contexts.yaml
+var contextsYAML []byte
+
+// contextDescription describes the properties matched by a pattern of a context property path.
+type contextDescription struct {
+	pattern     string
+	wildcards   int
+	description string
+}
+
+// contextDescriptions are the known context descriptions, with exact patterns before those with wildcards.
+var contextDescriptions = loadContextDescriptions(contextsYAML)
+
+func loadContextDescriptions(content []byte) []contextDescription {
+	table := map[string]string{}
+	if err := yaml.Unmarshal(content, &table); err != nil {
+		panic(fmt.Sprintf("invalid table of known contexts: %s", err))
+	}
+
+	descriptions := make([]contextDescription, 0, len(table))
+	for pattern, description := range table {
+		descriptions = append(descriptions, contextDescription{pattern, strings.Count(pattern, "*"), description})
+	}
+
+	sort.Slice(descriptions, func(a, b int) bool {
+		if descriptions[a].wildcards != descriptions[b].wildcards {
+			return descriptions[a].wildcards < descriptions[b].wildcards
+		}
+
+		return descriptions[a].pattern < descriptions[b].pattern
+	})
+
+	return descriptions
+}
+
+// match returns the description of a property path if the pattern matches it.
+func (c contextDescription) match(path []string) (string, bool) {
+	pattern := strings.Split(c.pattern, ".")
+	if len(path) != len(pattern) {
+		return "", false
+	}
+
+	description := c.description
+	wildcards := 0
+
+	for idx, property := range pattern {
+		switch property {
+		case "*":
+			description = strings.ReplaceAll(description, fmt.Sprintf("{%d}", wildcards), path[idx])
+			wildcards++
+		case path[idx]:
+		default:
+			return "", false
+		}
+	}
+
+	return description, true
+}
+
+// Describe returns a plain English description of an expression, such as "the workflow's GITHUB_TOKEN" for
+// github.token, if it only refers to known context properties. Alternatives joined with || are described in turn, and
+// may end with a string literal.
+func Describe(node Node) (string, bool) {
+	switch n := node.(type) {
+	case *BinaryOperation:
+		if n.Operator != Or {
+			return "", false
+		}
+
+		left, ok := Describe(n.Left)
+		if !ok {
+			return "", false
+		}
+
+		// Fallbacks are often string literals, e.g. github.head_ref || 'main'.
+		if literal, ok := n.Right.(*Literal); ok && literal.Kind == StringLiteral {
+			return fmt.Sprintf("%s, or else %q", left, literal.Value), true
+		}
+
+		right, ok := Describe(n.Right)
+		if !ok {
+			return "", false
+		}
+
+		return fmt.Sprintf("%s, or else %s", left, right), true
+	case *Context, *PropertyAccess, *IndexAccess:
+		base, path, indices := flattenAccess(node)
+
+		context, ok := base.(*Context)
+		if !ok || len(indices) != 0 {
+			return "", false
+		}
+
+		path = append([]string{context.Name}, path...)
+
+		for _, description := range contextDescriptions {
+			if described, ok := description.match(path); ok {
+				return described, true
+			}
+		}
+	}
+
+	return "", false
+}
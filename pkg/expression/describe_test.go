@@ -0,0 +1,78 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/expression"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	for input, expected := range map[string]string{
+		"github.token":                   "the workflow's GITHUB_TOKEN",
+		"secrets.GITHUB_TOKEN":           "the workflow's GITHUB_TOKEN",
+		"secrets['NPM_TOKEN']":           "the NPM_TOKEN secret",
+		"env.HOME":                       "the HOME environment variable",
+		"steps.build.outputs.path":       "the path output of the build step",
+		"github.head_ref || 'main'":      "the source branch of the pull request, or else \"main\"",
+		"inputs.a || inputs.b || vars.c": "the a input, or else the b input, or else the c configuration variable",
+		"github.workspace": "the workspace directory on the runner, where the repository is checked out to " +
+			"by default",
+	} {
+		node, err := expression.Parse(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		description, ok := expression.Describe(node)
+		assert.True(t, ok, input)
+		assert.Equal(t, expected, description, input)
+	}
+}
+
+func TestDescribeUnknown(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		"github.unknown",
+		"github",
+		"steps.build.outputs",
+		"env[inputs.name]",
+		"hashFiles('**/go.sum')",
+		"github.token && github.sha",
+		"github.token || 1",
+		"'literal'",
+	} {
+		node, err := expression.Parse(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, ok := expression.Describe(node)
+		assert.False(t, ok, input)
+	}
+}
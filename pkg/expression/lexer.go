@@ -0,0 +1,227 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package expression
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TokenKind is the kind of a token in an expression.
+type TokenKind int
+
+const (
+	EOF TokenKind = iota
+	Identifier
+	Number
+	String
+	Dot
+	Star
+	Comma
+	LeftParen
+	RightParen
+	LeftBracket
+	RightBracket
+	Not
+	Equal
+	NotEqual
+	Less
+	LessEqual
+	Greater
+	GreaterEqual
+	And
+	Or
+)
+
+var tokenKindNames = map[TokenKind]string{
+	EOF:          "end of expression",
+	Identifier:   "identifier",
+	Number:       "number",
+	String:       "string",
+	Dot:          "'.'",
+	Star:         "'*'",
+	Comma:        "','",
+	LeftParen:    "'('",
+	RightParen:   "')'",
+	LeftBracket:  "'['",
+	RightBracket: "']'",
+	Not:          "'!'",
+	Equal:        "'=='",
+	NotEqual:     "'!='",
+	Less:         "'<'",
+	LessEqual:    "'<='",
+	Greater:      "'>'",
+	GreaterEqual: "'>='",
+	And:          "'&&'",
+	Or:           "'||'",
+}
+
+func (k TokenKind) String() string {
+	return tokenKindNames[k]
+}
+
+// operators maps each operator and punctuation token to its kind, longest first so that e.g. <= isn't lexed as <.
+var operators = []struct {
+	text string
+	kind TokenKind
+}{
+	{"==", Equal},
+	{"!=", NotEqual},
+	{"<=", LessEqual},
+	{">=", GreaterEqual},
+	{"&&", And},
+	{"||", Or},
+	{".", Dot},
+	{"*", Star},
+	{",", Comma},
+	{"(", LeftParen},
+	{")", RightParen},
+	{"[", LeftBracket},
+	{"]", RightBracket},
+	{"!", Not},
+	{"<", Less},
+	{">", Greater},
+}
+
+// Token is a single token of an expression.
+type Token struct {
+	Kind TokenKind
+	// Value is the text of the token, with the quotes removed and escapes replaced for strings.
+	Value string
+	// Pos is the byte offset of the start of the token in the expression.
+	Pos int
+}
+
+// SyntaxError is returned when an expression can't be tokenized or parsed.
+type SyntaxError struct {
+	Pos     int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Message)
+}
+
+// Tokenize splits an expression, without its ${{ }} delimiters, into tokens. The last token is always EOF.
+func Tokenize(input string) ([]Token, error) {
+	var tokens []Token
+
+	pos := 0
+
+outer:
+	for pos < len(input) {
+		char := input[pos]
+
+		switch {
+		case isSpace(char):
+			pos++
+		case char == '\'':
+			value, end, err := lexString(input, pos)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, Token{Kind: String, Value: value, Pos: pos})
+			pos = end
+		case isDigit(char) || (char == '-' && pos+1 < len(input) && isDigit(input[pos+1])):
+			end := lexWhile(input, pos+1, isNumberChar)
+			tokens = append(tokens, Token{Kind: Number, Value: input[pos:end], Pos: pos})
+			pos = end
+		case isIdentifierStart(char):
+			end := lexWhile(input, pos+1, isIdentifierChar)
+			tokens = append(tokens, Token{Kind: Identifier, Value: input[pos:end], Pos: pos})
+			pos = end
+		default:
+			for _, op := range operators {
+				if strings.HasPrefix(input[pos:], op.text) {
+					tokens = append(tokens, Token{Kind: op.kind, Value: op.text, Pos: pos})
+					pos += len(op.text)
+
+					continue outer
+				}
+			}
+
+			return nil, &SyntaxError{Pos: pos, Message: fmt.Sprintf("unexpected character %q", char)}
+		}
+	}
+
+	return append(tokens, Token{Kind: EOF, Pos: len(input)}), nil
+}
+
+// lexString lexes the string literal starting at pos, returning its value and the offset just after it. Quotes are
+// escaped within strings by doubling them.
+func lexString(input string, pos int) (string, int, error) {
+	var value strings.Builder
+
+	for idx := pos + 1; idx < len(input); idx++ {
+		if input[idx] != '\'' {
+			value.WriteByte(input[idx])
+			continue
+		}
+
+		if idx+1 < len(input) && input[idx+1] == '\'' {
+			value.WriteByte('\'')
+			idx++
+
+			continue
+		}
+
+		return value.String(), idx + 1, nil
+	}
+
+	return "", 0, &SyntaxError{Pos: pos, Message: "unterminated string"}
+}
+
+func lexWhile(input string, pos int, matches func(byte) bool) int {
+	for pos < len(input) && matches(input[pos]) {
+		pos++
+	}
+
+	return pos
+}
+
+func isSpace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\n' || char == '\r'
+}
+
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
+
+func isLetter(char byte) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
+
+// isNumberChar reports whether a character can continue a number, covering decimals, exponents and hexadecimal.
+func isNumberChar(char byte) bool {
+	return isDigit(char) || isLetter(char) || char == '.' || char == '+' || char == '-'
+}
+
+func isIdentifierStart(char byte) bool {
+	return isLetter(char) || char == '_'
+}
+
+// isIdentifierChar reports whether a character can continue an identifier. Property names can contain dashes, e.g.
+// inputs.python-version.
+func isIdentifierChar(char byte) bool {
+	return isIdentifierStart(char) || isDigit(char) || char == '-'
+}
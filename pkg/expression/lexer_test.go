@@ -0,0 +1,78 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/expression"
+)
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+
+	tokens, err := expression.Tokenize("inputs.python-version >= '3.10' && !contains(x[0], 'it''s') || -1.5e3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(
+		t,
+		[]expression.Token{
+			{Kind: expression.Identifier, Value: "inputs", Pos: 0},
+			{Kind: expression.Dot, Value: ".", Pos: 6},
+			{Kind: expression.Identifier, Value: "python-version", Pos: 7},
+			{Kind: expression.GreaterEqual, Value: ">=", Pos: 22},
+			{Kind: expression.String, Value: "3.10", Pos: 25},
+			{Kind: expression.And, Value: "&&", Pos: 32},
+			{Kind: expression.Not, Value: "!", Pos: 35},
+			{Kind: expression.Identifier, Value: "contains", Pos: 36},
+			{Kind: expression.LeftParen, Value: "(", Pos: 44},
+			{Kind: expression.Identifier, Value: "x", Pos: 45},
+			{Kind: expression.LeftBracket, Value: "[", Pos: 46},
+			{Kind: expression.Number, Value: "0", Pos: 47},
+			{Kind: expression.RightBracket, Value: "]", Pos: 48},
+			{Kind: expression.Comma, Value: ",", Pos: 49},
+			{Kind: expression.String, Value: "it's", Pos: 51},
+			{Kind: expression.RightParen, Value: ")", Pos: 58},
+			{Kind: expression.Or, Value: "||", Pos: 60},
+			{Kind: expression.Number, Value: "-1.5e3", Pos: 63},
+			{Kind: expression.EOF, Pos: 69},
+		},
+		tokens,
+	)
+}
+
+func TestTokenizeErrors(t *testing.T) {
+	t.Parallel()
+
+	for input, message := range map[string]string{
+		"'unterminated":  "syntax error at position 0: unterminated string",
+		"github.sha ; 1": "syntax error at position 11: unexpected character ';'",
+		"a = b":          "syntax error at position 2: unexpected character '='",
+	} {
+		_, err := expression.Tokenize(input)
+		assert.EqualError(t, err, message, input)
+	}
+}
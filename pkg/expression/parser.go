@@ -0,0 +1,291 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// binaryPrecedence lists the binary operators from lowest to highest precedence.
+var binaryPrecedence = [][]TokenKind{
+	{Or},
+	{And},
+	{Equal, NotEqual},
+	{Less, LessEqual, Greater, GreaterEqual},
+}
+
+type parser struct {
+	tokens []Token
+	pos    int
+}
+
+// Parse parses an expression, without its ${{ }} delimiters, into a syntax tree.
+func Parse(input string) (Node, error) {
+	tokens, err := Tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	node, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if token := p.peek(); token.Kind != EOF {
+		return nil, unexpected(token)
+	}
+
+	return node, nil
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() Token {
+	token := p.tokens[p.pos]
+	if token.Kind != EOF {
+		p.pos++
+	}
+
+	return token
+}
+
+func (p *parser) expect(kind TokenKind) (Token, error) {
+	token := p.next()
+	if token.Kind != kind {
+		return token, &SyntaxError{
+			Pos:     token.Pos,
+			Message: fmt.Sprintf("expected %s but found %s", kind, describe(token)),
+		}
+	}
+
+	return token, nil
+}
+
+// parseBinary parses operations of the operators at the given level of precedence and above, which are all left
+// associative.
+func (p *parser) parseBinary(level int) (Node, error) {
+	if level == len(binaryPrecedence) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for containsKind(binaryPrecedence[level], p.peek().Kind) {
+		operator := p.next()
+
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		left = &BinaryOperation{Pos: left.Position(), Operator: operator.Kind, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if p.peek().Kind != Not {
+		return p.parsePostfix()
+	}
+
+	operator := p.next()
+
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &UnaryOperation{Pos: operator.Pos, Operator: operator.Kind, Operand: operand}, nil
+}
+
+// parsePostfix parses a primary expression followed by any number of property or index accesses.
+func (p *parser) parsePostfix() (Node, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch p.peek().Kind {
+		case Dot:
+			p.next()
+
+			property := p.next()
+			if property.Kind != Identifier && property.Kind != Star {
+				return nil, &SyntaxError{
+					Pos:     property.Pos,
+					Message: fmt.Sprintf("expected property name but found %s", describe(property)),
+				}
+			}
+
+			node = &PropertyAccess{Pos: node.Position(), Object: node, Property: property.Value}
+		case LeftBracket:
+			p.next()
+
+			// An index of * is an object filter, the same as a property access of *.
+			if p.peek().Kind == Star {
+				p.next()
+
+				node = &PropertyAccess{Pos: node.Position(), Object: node, Property: "*"}
+			} else {
+				index, err := p.parseBinary(0)
+				if err != nil {
+					return nil, err
+				}
+
+				node = &IndexAccess{Pos: node.Position(), Object: node, Index: index}
+			}
+
+			if _, err = p.expect(RightBracket); err != nil {
+				return nil, err
+			}
+		default:
+			return node, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	token := p.next()
+
+	switch token.Kind {
+	case Number:
+		if !isValidNumber(token.Value) {
+			return nil, &SyntaxError{Pos: token.Pos, Message: fmt.Sprintf("invalid number %q", token.Value)}
+		}
+
+		return &Literal{Pos: token.Pos, Kind: NumberLiteral, Value: token.Value}, nil
+	case String:
+		return &Literal{Pos: token.Pos, Kind: StringLiteral, Value: token.Value}, nil
+	case Identifier:
+		switch token.Value {
+		case "null":
+			return &Literal{Pos: token.Pos, Kind: NullLiteral, Value: token.Value}, nil
+		case "true", "false":
+			return &Literal{Pos: token.Pos, Kind: BooleanLiteral, Value: token.Value}, nil
+		case "NaN", "Infinity":
+			return &Literal{Pos: token.Pos, Kind: NumberLiteral, Value: token.Value}, nil
+		}
+
+		if p.peek().Kind == LeftParen {
+			return p.parseFunctionCall(token)
+		}
+
+		return &Context{Pos: token.Pos, Name: token.Value}, nil
+	case LeftParen:
+		node, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = p.expect(RightParen); err != nil {
+			return nil, err
+		}
+
+		return node, nil
+	default:
+		return nil, unexpected(token)
+	}
+}
+
+func (p *parser) parseFunctionCall(name Token) (Node, error) {
+	p.next()
+
+	call := &FunctionCall{Pos: name.Pos, Name: name.Value}
+
+	if p.peek().Kind == RightParen {
+		p.next()
+		return call, nil
+	}
+
+	for {
+		arg, err := p.parseBinary(0)
+		if err != nil {
+			return nil, err
+		}
+
+		call.Arguments = append(call.Arguments, arg)
+
+		token := p.next()
+
+		switch token.Kind {
+		case Comma:
+			continue
+		case RightParen:
+			return call, nil
+		default:
+			return nil, &SyntaxError{
+				Pos:     token.Pos,
+				Message: fmt.Sprintf("expected ',' or ')' but found %s", describe(token)),
+			}
+		}
+	}
+}
+
+func isValidNumber(value string) bool {
+	unsigned := strings.TrimPrefix(value, "-")
+	if strings.HasPrefix(unsigned, "0x") {
+		_, err := strconv.ParseInt(unsigned[2:], 16, 64)
+		return err == nil
+	}
+
+	_, err := strconv.ParseFloat(value, 64)
+
+	return err == nil
+}
+
+func containsKind(kinds []TokenKind, kind TokenKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+func describe(token Token) string {
+	switch token.Kind {
+	case EOF:
+		return token.Kind.String()
+	case Identifier, Number:
+		return fmt.Sprintf("%s %s", token.Kind, token.Value)
+	case String:
+		return fmt.Sprintf("string '%s'", token.Value)
+	default:
+		return token.Kind.String()
+	}
+}
+
+func unexpected(token Token) error {
+	return &SyntaxError{Pos: token.Pos, Message: fmt.Sprintf("unexpected %s", describe(token))}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/expression"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	node, err := expression.Parse("!cancelled() && github.event.pull_request.draft == false")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(
+		t,
+		&expression.BinaryOperation{
+			Pos:      0,
+			Operator: expression.And,
+			Left: &expression.UnaryOperation{
+				Pos:      0,
+				Operator: expression.Not,
+				Operand:  &expression.FunctionCall{Pos: 1, Name: "cancelled"},
+			},
+			Right: &expression.BinaryOperation{
+				Pos:      16,
+				Operator: expression.Equal,
+				Left: &expression.PropertyAccess{
+					Pos: 16,
+					Object: &expression.PropertyAccess{
+						Pos: 16,
+						Object: &expression.PropertyAccess{
+							Pos:      16,
+							Object:   &expression.Context{Pos: 16, Name: "github"},
+							Property: "event",
+						},
+						Property: "pull_request",
+					},
+					Property: "draft",
+				},
+				Right: &expression.Literal{Pos: 51, Kind: expression.BooleanLiteral, Value: "false"},
+			},
+		},
+		node,
+	)
+}
+
+func TestParseFormatting(t *testing.T) {
+	t.Parallel()
+
+	for input, expected := range map[string]string{
+		"a || b && c":                          "a || (b && c)",
+		"(a || b) && c":                        "(a || b) && c",
+		"a == b != c":                          "(a == b) != c",
+		"a < 1 == true":                        "(a < 1) == true",
+		"!!a":                                  "!!a",
+		"!(a == b)":                            "!(a == b)",
+		"steps.*.outcome":                      "steps.*.outcome",
+		"steps[*].outcome":                     "steps.*.outcome",
+		"inputs['name']":                       "inputs['name']",
+		"format('{0} ''x''', github.sha)":      "format('{0} ''x''', github.sha)",
+		"fromJSON(needs.setup.outputs.matrix)": "fromJSON(needs.setup.outputs.matrix)",
+		"toJSON(null)":                         "toJSON(null)",
+		"0xff > -1.5 && NaN != Infinity":       "(0xff > -1.5) && (NaN != Infinity)",
+		"contains(fromJSON('[1]')[0], 1)":      "contains(fromJSON('[1]')[0], 1)",
+	} {
+		node, err := expression.Parse(input)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, expected, node.String(), input)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	for input, message := range map[string]string{
+		"":                  "syntax error at position 0: unexpected end of expression",
+		"github.":           "syntax error at position 7: expected property name but found end of expression",
+		"github.sha github": "syntax error at position 11: unexpected identifier github",
+		"format('a', )":     "syntax error at position 12: unexpected ')'",
+		"format('a' 'b')":   "syntax error at position 11: expected ',' or ')' but found string 'b'",
+		"(a":                "syntax error at position 2: expected ')' but found end of expression",
+		"a[1":               "syntax error at position 3: expected ']' but found end of expression",
+		"1abc":              "syntax error at position 0: invalid number \"1abc\"",
+		"a &&":              "syntax error at position 4: unexpected end of expression",
+	} {
+		_, err := expression.Parse(input)
+		assert.EqualError(t, err, message, input)
+	}
+}
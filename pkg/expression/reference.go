@@ -0,0 +1,103 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package expression
+
+import "strings"
+
+// Reference is a reference to a context, or a property within it, made by an expression.
+type Reference struct {
+	// Context is the name of the context referenced, e.g. inputs.
+	Context string
+	// Path is the property path followed within the context, e.g. [name] for inputs.name or inputs['name']. It stops
+	// at the first index which isn't a literal, and contains * for object filters.
+	Path []string
+	// Pos is the byte offset of the reference in the expression.
+	Pos int
+}
+
+func (r Reference) String() string {
+	return strings.Join(append([]string{r.Context}, r.Path...), ".")
+}
+
+// References returns every context reference in a syntax tree, in the order they appear.
+func References(node Node) []Reference {
+	var refs []Reference
+
+	collectReferences(node, &refs)
+
+	return refs
+}
+
+func collectReferences(node Node, refs *[]Reference) {
+	switch n := node.(type) {
+	case *Context, *PropertyAccess, *IndexAccess:
+		base, path, indices := flattenAccess(node)
+
+		if context, ok := base.(*Context); ok {
+			*refs = append(*refs, Reference{Context: context.Name, Path: path, Pos: context.Pos})
+		} else {
+			collectReferences(base, refs)
+		}
+
+		for _, index := range indices {
+			collectReferences(index, refs)
+		}
+	case *FunctionCall:
+		for _, arg := range n.Arguments {
+			collectReferences(arg, refs)
+		}
+	case *UnaryOperation:
+		collectReferences(n.Operand, refs)
+	case *BinaryOperation:
+		collectReferences(n.Left, refs)
+		collectReferences(n.Right, refs)
+	}
+}
+
+// flattenAccess splits a chain of property and index accesses into the node being accessed, the static property path
+// accessed on it, and the indices which aren't literals. The path stops at the first index which isn't a literal.
+func flattenAccess(node Node) (base Node, path []string, indices []Node) {
+	switch n := node.(type) {
+	case *PropertyAccess:
+		base, path, indices = flattenAccess(n.Object)
+		if len(indices) == 0 {
+			path = append(path, n.Property)
+		}
+
+		return base, path, indices
+	case *IndexAccess:
+		base, path, indices = flattenAccess(n.Object)
+
+		literal, ok := n.Index.(*Literal)
+
+		switch {
+		case !ok:
+			indices = append(indices, n.Index)
+		case len(indices) == 0:
+			path = append(path, literal.Value)
+		}
+
+		return base, path, indices
+	default:
+		return node, nil, nil
+	}
+}
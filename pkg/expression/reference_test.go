@@ -0,0 +1,58 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/expression"
+)
+
+func TestReferences(t *testing.T) {
+	t.Parallel()
+
+	node, err := expression.Parse(
+		"format('{0}', inputs['name']) || steps.*.outcome == matrix[inputs.key].value && fromJSON(env.X).y && github",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refs := expression.References(node)
+
+	assert.Equal(
+		t,
+		[]expression.Reference{
+			{Context: "inputs", Path: []string{"name"}, Pos: 14},
+			{Context: "steps", Path: []string{"*", "outcome"}, Pos: 33},
+			{Context: "matrix", Path: nil, Pos: 52},
+			{Context: "inputs", Path: []string{"key"}, Pos: 59},
+			{Context: "env", Path: []string{"X"}, Pos: 89},
+			{Context: "github", Path: nil, Pos: 101},
+		},
+		refs,
+	)
+	assert.Equal(t, "inputs.name", refs[0].String())
+	assert.Equal(t, "steps.*.outcome", refs[1].String())
+}
@@ -0,0 +1,115 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package expression
+
+import "strings"
+
+const (
+	openDelimiter  = "${{"
+	closeDelimiter = "}}"
+)
+
+// Expression is an expression embedded in text between ${{ }} delimiters.
+type Expression struct {
+	// Raw is the expression including its delimiters.
+	Raw string
+	// Source is the expression between its delimiters.
+	Source string
+	// Offset is the byte offset of the start of the expression, including its delimiters, in the text.
+	Offset int
+	// Node is the syntax tree of the expression, or nil if it couldn't be parsed.
+	Node Node
+	// Err is the error parsing the expression, if any.
+	Err error
+}
+
+// FindAll finds and parses every expression embedded in text. Expressions which can't be parsed are still returned,
+// with their error set.
+func FindAll(text string) []Expression {
+	var expressions []Expression
+
+	pos := 0
+
+	for {
+		start := strings.Index(text[pos:], openDelimiter)
+		if start == -1 {
+			return expressions
+		}
+
+		start += pos
+		sourceStart := start + len(openDelimiter)
+
+		end := findClose(text, sourceStart)
+		if end == -1 {
+			expressions = append(expressions, Expression{
+				Raw:    text[start:],
+				Source: text[sourceStart:],
+				Offset: start,
+				Err:    &SyntaxError{Pos: 0, Message: "unterminated expression"},
+			})
+
+			return expressions
+		}
+
+		expr := Expression{
+			Raw:    text[start : end+len(closeDelimiter)],
+			Source: text[sourceStart:end],
+			Offset: start,
+		}
+		expr.Node, expr.Err = Parse(expr.Source)
+
+		expressions = append(expressions, expr)
+		pos = end + len(closeDelimiter)
+	}
+}
+
+// findClose returns the offset of the delimiter closing the expression whose source starts at pos, skipping over
+// string literals which could contain it, or -1 if there isn't one.
+func findClose(text string, pos int) int {
+	inString := false
+
+	for idx := pos; idx < len(text); idx++ {
+		switch {
+		case text[idx] == '\'':
+			// Escaped quotes toggle the state twice, so need no special handling.
+			inString = !inString
+		case !inString && strings.HasPrefix(text[idx:], closeDelimiter):
+			return idx
+		}
+	}
+
+	return -1
+}
+
+// SourceOffset returns the byte offset in the text of a position within the expression's source.
+func (e Expression) SourceOffset(pos int) int {
+	return e.Offset + len(openDelimiter) + pos
+}
+
+// References returns the context references made by the expression, or nil if it couldn't be parsed.
+func (e Expression) References() []Reference {
+	if e.Node == nil {
+		return nil
+	}
+
+	return References(e.Node)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package expression_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/expression"
+)
+
+func TestFindAll(t *testing.T) {
+	t.Parallel()
+
+	text := "echo ${{ inputs.a }} ${{ format('}}', github.sha) }} ${{ a b }}"
+
+	expressions := expression.FindAll(text)
+	if !assert.Len(t, expressions, 3) {
+		return
+	}
+
+	assert.Equal(t, "${{ inputs.a }}", expressions[0].Raw)
+	assert.Equal(t, " inputs.a ", expressions[0].Source)
+	assert.Equal(t, 5, expressions[0].Offset)
+	assert.NoError(t, expressions[0].Err)
+	assert.Equal(t, "inputs.a", expressions[0].Node.String())
+
+	assert.Equal(t, "${{ format('}}', github.sha) }}", expressions[1].Raw)
+	assert.Equal(t, 21, expressions[1].Offset)
+	assert.Equal(t, "format('}}', github.sha)", expressions[1].Node.String())
+
+	assert.Equal(t, "${{ a b }}", expressions[2].Raw)
+	assert.Nil(t, expressions[2].Node)
+	assert.Error(t, expressions[2].Err)
+	assert.Nil(t, expressions[2].References())
+}
+
+func TestFindAllUnterminated(t *testing.T) {
+	t.Parallel()
+
+	expressions := expression.FindAll("echo ${{ inputs.a }} ${{ inputs.b")
+	if assert.Len(t, expressions, 2) {
+		assert.NoError(t, expressions[0].Err)
+		assert.Equal(t, "${{ inputs.b", expressions[1].Raw)
+		assert.EqualError(t, expressions[1].Err, "syntax error at position 0: unterminated expression")
+	}
+
+	assert.Empty(t, expression.FindAll("no expressions here"))
+}
+
+func TestSourceOffset(t *testing.T) {
+	t.Parallel()
+
+	text := "echo ${{ inputs.a }}"
+	expr := expression.FindAll(text)[0]
+	ref := expr.References()[0]
+
+	assert.Equal(t, "inputs.a }}", text[expr.SourceOffset(ref.Pos):])
+}
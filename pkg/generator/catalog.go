@@ -0,0 +1,104 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package generator
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/matty-rose/gha-docs/pkg/document"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+// CatalogGenerator generates a summary of every action in a repository.
+type CatalogGenerator interface {
+	GenerateCatalog(entries []types.CatalogEntry) string
+}
+
+func NewCatalog(config Config) (CatalogGenerator, error) {
+	switch config.Format {
+	case "markdown":
+		return markdownGenerator{config}, nil
+	case "html":
+		return htmlGenerator{markdownGenerator{config}}, nil
+	}
+
+	return nil, errors.New("unsupported format")
+}
+
+// GenerateCatalog generates a table of the actions, linking to the directory of each action relative to the
+// repository root.
+func (mdg markdownGenerator) GenerateCatalog(entries []types.CatalogEntry) string {
+	doc := document.NewMarkdownDocument()
+
+	if len(entries) == 0 {
+		doc.WriteTextLn("No actions.")
+		return doc.Render()
+	}
+
+	columns := []string{"Name", "Path", "Description", "Inputs", "Outputs", "Runtime"}
+
+	var rows [][]string
+
+	sort.Slice(entries, func(a, b int) bool {
+		return entries[a].Dir < entries[b].Dir
+	})
+
+	for _, entry := range entries {
+		// Table rows can't span multiple lines.
+		description := strings.ReplaceAll(strings.TrimSpace(entry.Action.Description), "\n", " ")
+
+		rows = append(
+			rows,
+			[]string{
+				doc.CreateLink(entry.Action.Name, catalogLink(entry.Dir)),
+				doc.FormatCode(entry.Dir),
+				description,
+				strconv.Itoa(len(entry.Action.Inputs)),
+				strconv.Itoa(len(entry.Action.Outputs)),
+				doc.FormatCode(entry.Action.Using),
+			},
+		)
+	}
+
+	_, _ = doc.WriteTable(columns, rows)
+
+	return doc.Render()
+}
+
+// catalogLink returns the relative link to the directory of an action, which is "./" for an action at the
+// repository root.
+func catalogLink(dir string) string {
+	if dir = path.Clean(dir); dir == "." {
+		return "./"
+	}
+
+	return "./" + dir
+}
+
+func (hg htmlGenerator) GenerateCatalog(entries []types.CatalogEntry) string {
+	return document.MarkdownToHTML(hg.markdown.GenerateCatalog(entries))
+}
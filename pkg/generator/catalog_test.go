@@ -0,0 +1,116 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/generator"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+func TestGenerateMarkdownCatalog(t *testing.T) {
+	t.Parallel()
+
+	g, err := generator.NewCatalog(generator.Config{Format: "markdown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := []types.CatalogEntry{
+		{
+			Dir: "deploy",
+			Action: &types.CompositeAction{
+				Name:        "deploy",
+				Description: "Deploys",
+				Using:       "node16",
+				Outputs:     []types.Output{{Name: "url"}},
+			},
+		},
+		{
+			Dir: ".github/actions/build",
+			Action: &types.CompositeAction{
+				Name:        "build",
+				Description: "Builds",
+				Using:       "composite",
+				Inputs:      []types.Input{{Name: "a"}, {Name: "b"}},
+			},
+		},
+	}
+
+	expected := `| Name | Path | Description | Inputs | Outputs | Runtime |
+| --- | --- | --- | --- | --- | --- |
+| [build](./.github/actions/build) | ` + "`.github/actions/build`" + ` | Builds | 2 | 0 | ` + "`composite`" + ` |
+| [deploy](./deploy) | ` + "`deploy`" + ` | Deploys | 0 | 1 | ` + "`node16`" + ` |
+`
+
+	assert.Equal(t, expected, g.GenerateCatalog(entries))
+}
+
+func TestGenerateMarkdownCatalogRootAndMultiLineDescription(t *testing.T) {
+	t.Parallel()
+
+	g, err := generator.NewCatalog(generator.Config{Format: "markdown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := []types.CatalogEntry{
+		{
+			Dir: ".",
+			Action: &types.CompositeAction{
+				Name:        "root",
+				Description: "Does one thing.\nThen another.\n",
+				Using:       "composite",
+			},
+		},
+	}
+
+	expected := `| Name | Path | Description | Inputs | Outputs | Runtime |
+| --- | --- | --- | --- | --- | --- |
+| [root](./) | ` + "`.`" + ` | Does one thing. Then another. | 0 | 0 | ` + "`composite`" + ` |
+`
+
+	assert.Equal(t, expected, g.GenerateCatalog(entries))
+}
+
+func TestGenerateMarkdownCatalogEmpty(t *testing.T) {
+	t.Parallel()
+
+	g, err := generator.NewCatalog(generator.Config{Format: "markdown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "No actions.\n", g.GenerateCatalog(nil))
+}
+
+func TestCatalogInvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	g, err := generator.NewCatalog(generator.Config{Format: "invalid"})
+
+	assert.Nil(t, g)
+	assert.Error(t, err)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package generator
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/matty-rose/gha-docs/pkg/document"
+)
+
+// Column configures a column of a table.
+type Column struct {
+	// ID identifies the content of the column, e.g. "required".
+	ID string
+	// Header overrides the default header of the column.
+	Header string
+	// Format sets how the values of a column of booleans, such as "required", are written. One of CheckFormat or
+	// YesNoFormat, defaulting to true/false.
+	Format string
+}
+
+const (
+	// CheckFormat writes booleans as ✅ or ❌.
+	CheckFormat = "check"
+	// YesNoFormat writes booleans as Yes or No.
+	YesNoFormat = "yes-no"
+)
+
+// columnDefinition describes a column which can be included in a table.
+type columnDefinition struct {
+	id      string
+	header  string
+	boolean bool
+}
+
+var inputColumns = []columnDefinition{
+	{id: "name", header: "Name"},
+	{id: "description", header: "Description"},
+	{id: "type", header: "Type"},
+	{id: "required", header: "Required", boolean: true},
+	{id: "default", header: "Default"},
+	{id: "security-notes", header: "Security Notes"},
+}
+
+var outputColumns = []columnDefinition{
+	{id: "name", header: "Name"},
+	{id: "description", header: "Description"},
+	{id: "value", header: "Value"},
+}
+
+var externalActionColumns = []columnDefinition{
+	{id: "name", header: "Name"},
+	{id: "description", header: "Description"},
+	{id: "creator", header: "Creator"},
+	{id: "version", header: "Version"},
+	{id: "step-name", header: "Step Name"},
+	{id: "step-id", header: "Step ID"},
+	{id: "inputs-valid", header: "Inputs Valid"},
+	{id: "arguments", header: "Arguments"},
+}
+
+// validateColumns checks that the columns configured for each table are all known, included at most once, and only
+// formatted if they hold booleans.
+func (c Config) validateColumns() error {
+	tables := []struct {
+		name        string
+		definitions []columnDefinition
+		columns     []Column
+	}{
+		{"inputs", inputColumns, c.InputColumns},
+		{"outputs", outputColumns, c.OutputColumns},
+		{"external actions", externalActionColumns, c.ExternalActionColumns},
+	}
+
+	for _, table := range tables {
+		included := map[string]bool{}
+
+		for _, column := range table.columns {
+			definition, ok := findColumn(table.definitions, column.ID)
+			if !ok {
+				return errors.Errorf("unknown %s table column %q", table.name, column.ID)
+			}
+
+			if included[column.ID] {
+				return errors.Errorf("%s table column %q is included more than once", table.name, column.ID)
+			}
+
+			included[column.ID] = true
+
+			if column.Format == "" {
+				continue
+			}
+
+			if column.Format != CheckFormat && column.Format != YesNoFormat {
+				return errors.Errorf("unknown format %q for %s table column %q", column.Format, table.name, column.ID)
+			}
+
+			if !definition.boolean {
+				return errors.Errorf("%s table column %q can't be formatted", table.name, column.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func findColumn(definitions []columnDefinition, id string) (columnDefinition, bool) {
+	for _, definition := range definitions {
+		if definition.id == id {
+			return definition, true
+		}
+	}
+
+	return columnDefinition{}, false
+}
+
+// showsArguments returns whether the external actions table has an arguments column, either because
+// ExternalActionArguments is set or because the column is configured by name.
+func (c Config) showsArguments() bool {
+	return c.ExternalActionArguments || hasColumn(c.ExternalActionColumns, "arguments")
+}
+
+func hasColumn(columns []Column, id string) bool {
+	for _, column := range columns {
+		if column.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// writeTable writes a table with the configured columns, or if none are configured, every column which is available,
+// e.g. security notes only when an input has any. Configured columns are always written, even if every value is empty.
+// Each row maps column IDs to their values, with booleans written by strconv.FormatBool.
+func writeTable(
+	doc *document.MarkdownDocument,
+	definitions []columnDefinition,
+	configured []Column,
+	available map[string]bool,
+	rows []map[string]string,
+) {
+	columns := configured
+	if len(columns) == 0 {
+		for _, definition := range definitions {
+			if available[definition.id] {
+				columns = append(columns, Column{ID: definition.id})
+			}
+		}
+	}
+
+	if len(columns) == 0 {
+		return
+	}
+
+	headers := make([]string, 0, len(columns))
+
+	for _, column := range columns {
+		header := column.Header
+		if header == "" {
+			definition, _ := findColumn(definitions, column.ID)
+			header = definition.header
+		}
+
+		headers = append(headers, header)
+	}
+
+	cells := make([][]string, 0, len(rows))
+
+	for _, row := range rows {
+		values := make([]string, 0, len(columns))
+		for _, column := range columns {
+			values = append(values, formatColumnValue(column.Format, row[column.ID]))
+		}
+
+		cells = append(cells, values)
+	}
+
+	_, _ = doc.WriteTable(headers, cells)
+}
+
+func formatColumnValue(format, value string) string {
+	boolean, err := strconv.ParseBool(value)
+	if err != nil {
+		return value
+	}
+
+	switch format {
+	case CheckFormat:
+		if boolean {
+			return "✅"
+		}
+
+		return "❌"
+	case YesNoFormat:
+		if boolean {
+			return "Yes"
+		}
+
+		return "No"
+	}
+
+	return value
+}
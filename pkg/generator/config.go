@@ -35,8 +35,87 @@ var UsageModeIDs = map[UsageMode][]string{
 	Local:  {"local"},
 }
 
+// ExampleInputsMode controls which inputs are included in the example usage block, and how.
+type ExampleInputsMode enumflag.Flag
+
+const (
+	// AllInputs includes every input, without a value.
+	AllInputs ExampleInputsMode = iota
+	// RequiredInputs includes only required inputs.
+	RequiredInputs
+	// DefaultInputs includes every input, set to its default value.
+	DefaultInputs
+	// CommentedInputs includes every input, set to its default value and commented out unless it's required.
+	CommentedInputs
+)
+
+var ExampleInputsModeIDs = map[ExampleInputsMode][]string{
+	AllInputs:       {"all"},
+	RequiredInputs:  {"required"},
+	DefaultInputs:   {"defaults"},
+	CommentedInputs: {"commented"},
+}
+
 type Config struct {
 	Format string
 
 	ExampleUsageMode *UsageMode
+
+	// ExampleInputs controls which inputs are included in the example usage block.
+	ExampleInputs ExampleInputsMode
+
+	// ExampleOutputs adds a step to the example usage block showing how to read each of the action's outputs.
+	ExampleOutputs bool
+
+	// ExampleWorkflow wraps the example usage block in a complete workflow, which checks out the repository before
+	// using the action.
+	ExampleWorkflow bool
+
+	// LocalActionLinks maps the path of a local action, relative to the repository root, to the link used for it in
+	// the external actions table. Local actions without an entry are linked to by their path.
+	LocalActionLinks map[string]string
+
+	// TransitiveExternalActions adds a table of every remote action used by the action, including through local
+	// actions, to the external actions section.
+	TransitiveExternalActions bool
+
+	// ExternalActionArguments adds the inputs and environment variables passed to each external action to the
+	// external actions table.
+	ExternalActionArguments bool
+
+	// Steps adds a section summarising each step of the action, if Sections isn't set.
+	Steps bool
+
+	// TableOfContents adds a list of links to each section after the description.
+	TableOfContents bool
+
+	// Warnings adds a section listing the problems found by static analysis of the action's run steps, if Sections
+	// isn't set.
+	Warnings bool
+
+	// HeadingLevel is the level of the action's name heading, with every other heading shifted to match, e.g. 2 to
+	// embed the documentation in a document with its own H1. Defaults to 1, and can be at most MaxHeadingLevel.
+	HeadingLevel int
+
+	// OmitHeader leaves out the action's name and description, e.g. when injecting into a document which already
+	// introduces the action.
+	OmitHeader bool
+
+	// Sections lists the sections to include, in order. If it isn't set, the inputs, outputs, external actions and
+	// example usage sections are included, along with the steps and warnings sections if enabled.
+	Sections []Section
+
+	// SectionTitles overrides the headings of sections.
+	SectionTitles map[Section]string
+
+	// SectionEmptyText overrides the text shown in sections with nothing to list, e.g. "No inputs.".
+	SectionEmptyText map[Section]string
+
+	// InputColumns, OutputColumns and ExternalActionColumns configure the columns of the inputs, outputs and external
+	// actions tables, in order. If they aren't set, every column is included, except for those which are only shown
+	// in some cases, such as the security notes of inputs, which are left out otherwise. Columns configured by name
+	// are always included, even if every value in them is empty.
+	InputColumns          []Column
+	OutputColumns         []Column
+	ExternalActionColumns []Column
 }
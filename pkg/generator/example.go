@@ -0,0 +1,320 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/matty-rose/gha-docs/pkg/examples"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+// exampleCommentWidth is the width, including indentation, that comments in the example usage block are wrapped to.
+const exampleCommentWidth = 80
+
+// exampleUsage returns the lines of the example usage block for an action.
+func (mdg markdownGenerator) exampleUsage(act *types.CompositeAction) []string {
+	lines := []string{fmt.Sprintf("- name: %s", yamlScalar(act.Name))}
+
+	showOutputs := mdg.config.ExampleOutputs && len(act.Outputs) != 0
+
+	// Steps are indented further when wrapped in a workflow, leaving less room for comments.
+	width := exampleCommentWidth
+	if mdg.config.ExampleWorkflow {
+		width -= len(exampleWorkflowIndent)
+	}
+
+	id := exampleStepID(act.Name)
+	if showOutputs {
+		lines = append(lines, fmt.Sprintf("  id: %s", id))
+	}
+
+	lines = append(lines, fmt.Sprintf("  uses: %s", mdg.exampleUses()))
+
+	sort.Slice(act.Inputs, func(a, b int) bool {
+		return act.Inputs[a].Name < act.Inputs[b].Name
+	})
+
+	var inputs []types.Input
+
+	for _, inp := range act.Inputs {
+		if mdg.config.ExampleInputs != RequiredInputs || inp.Required {
+			inputs = append(inputs, inp)
+		}
+	}
+
+	if len(inputs) != 0 {
+		lines = append(lines, "  with:")
+
+		for idx, inp := range inputs {
+			if idx != 0 {
+				lines = append(lines, "")
+			}
+
+			lines = append(lines, wrapComment(inp.Description, "    ", width)...)
+			lines = append(lines, "    "+mdg.exampleInput(inp))
+		}
+	}
+
+	if showOutputs {
+		lines = append(lines, exampleOutputsStep(act, id, width)...)
+	}
+
+	if mdg.config.ExampleWorkflow {
+		return exampleWorkflow(act, lines)
+	}
+
+	return lines
+}
+
+const (
+	// exampleCheckoutAction is the action used to check out the repository in example workflows.
+	exampleCheckoutAction = "actions/checkout@v4"
+	// exampleWorkflowIndent is the indentation of steps in example workflows.
+	exampleWorkflowIndent = "      "
+)
+
+// exampleWorkflow wraps the lines of the example steps in a workflow with a single job, which checks out the
+// repository first.
+func exampleWorkflow(act *types.CompositeAction, steps []string) []string {
+	lines := []string{
+		fmt.Sprintf("name: %s", yamlScalar(act.Name)),
+		"",
+		"on:",
+		"  push:",
+		"  pull_request:",
+		"",
+		"jobs:",
+		fmt.Sprintf("  %s:", exampleStepID(act.Name)),
+		"    runs-on: ubuntu-latest",
+		"    steps:",
+		exampleWorkflowIndent + "- uses: " + exampleCheckoutAction,
+		"",
+	}
+
+	for _, line := range steps {
+		if line != "" {
+			line = exampleWorkflowIndent + line
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// exampleUses returns the reference to the action used in the example usage block.
+func (mdg markdownGenerator) exampleUses() string {
+	if *mdg.config.ExampleUsageMode == Local {
+		return "./path/to/action.yml"
+	}
+
+	// TODO: Some way of getting actual owner/repo name here?
+	return "owner/repo@latest"
+}
+
+// verifyExample checks that the lines of the example usage block are valid YAML, use the action once, only pass
+// inputs the action declares and pass every input it requires.
+func (mdg markdownGenerator) verifyExample(act *types.CompositeAction, lines []string) error {
+	steps, err := examples.ParseSteps([]byte(strings.Join(lines, "\n")))
+	if err != nil {
+		return errors.Wrap(err, "generated an invalid example usage block")
+	}
+
+	var used []examples.Step
+
+	for _, step := range steps {
+		if step.Uses == mdg.exampleUses() {
+			used = append(used, step)
+		}
+	}
+
+	if len(used) != 1 {
+		return errors.Errorf("generated an example usage block using the action %d times rather than once", len(used))
+	}
+
+	if problems := examples.CheckInputs(act, used[0]); len(problems) != 0 {
+		return errors.Errorf(
+			"generated an example usage block inconsistent with the action: %s",
+			strings.Join(problems, ", "),
+		)
+	}
+
+	return nil
+}
+
+// exampleOutputsStep returns the lines of a step reading each output of the action from the step with the given ID.
+// Outputs are passed to the script through environment variables, rather than interpolated into it, to avoid script
+// injection.
+func exampleOutputsStep(act *types.CompositeAction, id string, width int) []string {
+	sort.Slice(act.Outputs, func(a, b int) bool {
+		return act.Outputs[a].Name < act.Outputs[b].Name
+	})
+
+	lines := []string{"", "- name: Use outputs", "  shell: bash", "  env:"}
+
+	variables := make([]string, 0, len(act.Outputs))
+
+	for _, out := range act.Outputs {
+		variable := exampleEnvVariable(out.Name)
+		variables = append(variables, variable)
+
+		lines = append(lines, wrapComment(out.Description, "    ", width)...)
+		lines = append(lines, fmt.Sprintf("    %s: ${{ steps.%s.outputs.%s }}", variable, id, out.Name))
+	}
+
+	lines = append(lines, "  run: |")
+
+	for _, variable := range variables {
+		lines = append(lines, fmt.Sprintf(`    echo "$%s"`, variable))
+	}
+
+	return lines
+}
+
+// exampleStepID returns a step ID for the action in the example usage block, derived from its name. IDs must start
+// with a letter or underscore, and only contain alphanumeric characters, dashes and underscores.
+func exampleStepID(name string) string {
+	var id strings.Builder
+
+	for _, char := range strings.ToLower(name) {
+		switch {
+		case (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') || char == '_':
+			id.WriteRune(char)
+		case id.Len() != 0 && !strings.HasSuffix(id.String(), "-"):
+			id.WriteRune('-')
+		}
+	}
+
+	slug := strings.TrimRight(id.String(), "-")
+
+	switch {
+	case slug == "":
+		return "action"
+	case slug[0] >= '0' && slug[0] <= '9':
+		return "action-" + slug
+	default:
+		return slug
+	}
+}
+
+// exampleEnvVariable returns the name of the environment variable an output is passed to the example script in.
+func exampleEnvVariable(output string) string {
+	return strings.Map(func(char rune) rune {
+		if (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9') || char == '_' {
+			return char
+		}
+
+		return '_'
+	}, strings.ToUpper(output))
+}
+
+// exampleInput returns the line setting an input in the example usage block, annotated with whether it's required
+// and its default value if that isn't filled in.
+func (mdg markdownGenerator) exampleInput(inp types.Input) string {
+	line := fmt.Sprintf("%s:", inp.Name)
+
+	filled := false
+	if inp.Default != "" && (mdg.config.ExampleInputs == DefaultInputs || mdg.config.ExampleInputs == CommentedInputs) {
+		line = fmt.Sprintf("%s %s", line, yamlScalar(inp.Default))
+		filled = true
+	}
+
+	if mdg.config.ExampleInputs == CommentedInputs && !inp.Required {
+		line = "# " + line
+	}
+
+	var annotations []string
+
+	if inp.Required {
+		annotations = append(annotations, "Required")
+	}
+
+	if inp.Default != "" && !filled {
+		annotations = append(annotations, fmt.Sprintf("Default: %s", strings.ReplaceAll(inp.Default, "\n", `\n`)))
+	}
+
+	if len(annotations) != 0 {
+		line = fmt.Sprintf("%s # %s", line, strings.Join(annotations, ", "))
+	}
+
+	return line
+}
+
+// wrapComment formats text as comment lines with the given indentation, wrapped to the given width. Line breaks in
+// the text are kept.
+func wrapComment(text, indent string, width int) []string {
+	text = strings.TrimRight(text, "\n")
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	width -= len(indent) + len("# ")
+
+	var lines []string
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, indent+"#")
+			continue
+		}
+
+		line := words[0]
+
+		for _, word := range words[1:] {
+			if len(line)+len(" ")+len(word) > width {
+				lines = append(lines, indent+"# "+line)
+				line = word
+
+				continue
+			}
+
+			line += " " + word
+		}
+
+		lines = append(lines, indent+"# "+line)
+	}
+
+	return lines
+}
+
+// yamlScalar formats a string as a YAML scalar, quoting it if needed. Multi-line strings are double quoted, rather
+// than written as block scalars, so they fit on a single line.
+func yamlScalar(value string) string {
+	if strings.Contains(value, "\n") {
+		return strconv.Quote(value)
+	}
+
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		return strconv.Quote(value)
+	}
+
+	return strings.TrimSuffix(string(out), "\n")
+}
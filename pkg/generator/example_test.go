@@ -0,0 +1,226 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package generator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/matty-rose/gha-docs/pkg/generator"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+type exampleWorkflow struct {
+	Name string                 `yaml:"name"`
+	On   map[string]interface{} `yaml:"on"`
+	Jobs map[string]struct {
+		RunsOn string `yaml:"runs-on"`
+		Steps  []struct {
+			Name  string            `yaml:"name"`
+			ID    string            `yaml:"id"`
+			Uses  string            `yaml:"uses"`
+			Shell string            `yaml:"shell"`
+			With  map[string]string `yaml:"with"`
+			Env   map[string]string `yaml:"env"`
+			Run   string            `yaml:"run"`
+		} `yaml:"steps"`
+	} `yaml:"jobs"`
+}
+
+// extractExample returns the content of the example usage code block in generated markdown.
+func extractExample(t *testing.T, content string) string {
+	t.Helper()
+
+	const start = "## Example Usage\n```yaml\n"
+
+	idx := strings.Index(content, start)
+	if idx == -1 {
+		t.Fatal("example usage block not found")
+	}
+
+	block := content[idx+len(start):]
+
+	end := strings.Index(block, "```\n")
+	if end == -1 {
+		t.Fatal("example usage block not terminated")
+	}
+
+	block = block[:end]
+
+	return block
+}
+
+func newExampleAction() types.CompositeAction {
+	return types.CompositeAction{
+		Name:        "Build: & Test",
+		Description: "Builds and tests.",
+		Inputs: []types.Input{
+			{Name: "path", Description: "The path to build.", Required: true},
+			{Name: "token", Description: "The token to use.", Default: "${{ github.token }}"},
+			{Name: "flags", Description: "Flags to pass.\nOne per line.", Default: "--a\n--b: c"},
+			{Name: "pattern", Description: "A pattern.", Default: "*.go #all"},
+			{Name: "debug", Description: "Whether to debug.", Default: "false"},
+		},
+		Outputs: []types.Output{{Name: "artifact-path", Description: "The artifact path.", Value: "x"}},
+	}
+}
+
+func TestGenerateMarkdownExampleWorkflow(t *testing.T) {
+	t.Parallel()
+
+	config := newMarkdownConfig(generator.Local)
+	config.ExampleWorkflow = true
+	config.ExampleOutputs = true
+	config.ExampleInputs = generator.DefaultInputs
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := newExampleAction()
+
+	var workflow exampleWorkflow
+	if err := yaml.Unmarshal([]byte(extractExample(t, generate(t, g, &action))), &workflow); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Build: & Test", workflow.Name)
+	assert.Contains(t, workflow.On, "push")
+	assert.Contains(t, workflow.On, "pull_request")
+
+	job, ok := workflow.Jobs["build-test"]
+	if !assert.True(t, ok) || !assert.Len(t, job.Steps, 3) {
+		return
+	}
+
+	assert.Equal(t, "ubuntu-latest", job.RunsOn)
+	assert.Equal(t, "actions/checkout@v4", job.Steps[0].Uses)
+
+	assert.Equal(t, "Build: & Test", job.Steps[1].Name)
+	assert.Equal(t, "build-test", job.Steps[1].ID)
+	assert.Equal(t, "./path/to/action.yml", job.Steps[1].Uses)
+	assert.Equal(
+		t,
+		map[string]string{
+			"debug":   "false",
+			"flags":   "--a\n--b: c",
+			"path":    "",
+			"pattern": "*.go #all",
+			"token":   "${{ github.token }}",
+		},
+		job.Steps[1].With,
+	)
+
+	assert.Equal(t, "bash", job.Steps[2].Shell)
+	assert.Equal(
+		t,
+		map[string]string{"ARTIFACT_PATH": "${{ steps.build-test.outputs.artifact-path }}"},
+		job.Steps[2].Env,
+	)
+	assert.Equal(t, "echo \"$ARTIFACT_PATH\"\n", job.Steps[2].Run)
+}
+
+func TestGenerateMarkdownExampleWorkflowInputModes(t *testing.T) {
+	t.Parallel()
+
+	for mode, keys := range map[generator.ExampleInputsMode][]string{
+		generator.AllInputs:       {"debug", "flags", "path", "pattern", "token"},
+		generator.RequiredInputs:  {"path"},
+		generator.DefaultInputs:   {"debug", "flags", "path", "pattern", "token"},
+		generator.CommentedInputs: {"path"},
+	} {
+		config := newMarkdownConfig(generator.Remote)
+		config.ExampleWorkflow = true
+		config.ExampleInputs = mode
+
+		g, err := generator.New(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		action := newExampleAction()
+
+		var workflow exampleWorkflow
+		if err := yaml.Unmarshal([]byte(extractExample(t, generate(t, g, &action))), &workflow); err != nil {
+			t.Fatal(mode, err)
+		}
+
+		steps := workflow.Jobs["build-test"].Steps
+		if assert.Len(t, steps, 2, mode) {
+			var with []string
+			for key := range steps[1].With {
+				with = append(with, key)
+			}
+
+			assert.ElementsMatch(t, keys, with, mode)
+		}
+	}
+}
+
+func TestGenerateMarkdownExampleWorkflowWrapping(t *testing.T) {
+	t.Parallel()
+
+	config := newMarkdownConfig(generator.Remote)
+	config.ExampleWorkflow = true
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name: "test",
+		Inputs: []types.Input{
+			{Name: "a", Description: strings.Repeat("word ", 40)},
+		},
+	}
+
+	for _, line := range strings.Split(extractExample(t, generate(t, g, &action)), "\n") {
+		assert.LessOrEqual(t, len(line), 80, line)
+	}
+}
+
+func TestGenerateMarkdownExampleVerified(t *testing.T) {
+	t.Parallel()
+
+	g, err := generator.New(newMarkdownConfig(generator.Remote))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Input names aren't quoted in the example, so this one is written as a comment.
+	action := types.CompositeAction{
+		Name:   "test",
+		Inputs: []types.Input{{Name: "#hash", Description: "A badly named input.", Required: true}},
+	}
+
+	_, err = g.Generate(&action)
+	assert.EqualError(
+		t,
+		err,
+		"generated an example usage block inconsistent with the action: missing required input #hash",
+	)
+}
@@ -24,17 +24,37 @@ package generator
 import (
 	"github.com/pkg/errors"
 
+	"github.com/matty-rose/gha-docs/pkg/document"
 	"github.com/matty-rose/gha-docs/pkg/types"
 )
 
+// MaxHeadingLevel is the deepest level the action's name heading can be written at, as sub-headings, such as a step's
+// heading, are written two levels below it and can't be deeper than H6.
+const MaxHeadingLevel = int(document.H6) - 2
+
 type Generator interface {
-	Generate(action *types.CompositeAction) string
+	Generate(action *types.CompositeAction) (string, error)
 }
 
 func New(config Config) (Generator, error) {
+	if err := config.validateSections(); err != nil {
+		return nil, err
+	}
+
+	if err := config.validateColumns(); err != nil {
+		return nil, err
+	}
+
+	// A heading level of zero is unset, and defaults to 1.
+	if config.HeadingLevel < 0 || config.HeadingLevel > MaxHeadingLevel {
+		return nil, errors.Errorf("heading level must be between 1 and %d, got %d", MaxHeadingLevel, config.HeadingLevel)
+	}
+
 	switch config.Format {
 	case "markdown":
 		return markdownGenerator{config}, nil
+	case "html":
+		return htmlGenerator{markdownGenerator{config}}, nil
 	}
 
 	return nil, errors.New("unsupported format")
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/matty-rose/gha-docs/pkg/generator"
+	"github.com/matty-rose/gha-docs/pkg/types"
 )
 
 func TestInvalidFormat(t *testing.T) {
@@ -39,3 +40,30 @@ func TestInvalidFormat(t *testing.T) {
 	assert.Nil(t, g)
 	assert.Error(t, err)
 }
+
+func TestHTMLFormat(t *testing.T) {
+	t.Parallel()
+
+	mode := generator.Local
+	local := "./.github/actions/other"
+	config := generator.Config{
+		Format:           "html",
+		ExampleUsageMode: &mode,
+		LocalActionLinks: map[string]string{".github/actions/other": "other.html"},
+	}
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := generate(t, g, &types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		Uses:        []types.ExternalAction{{Name: "other", Local: true, LocalPath: &local}},
+	})
+
+	assert.Contains(t, content, "<h1 id=\"test\">test</h1>\n<p>also test</p>\n")
+	assert.Contains(t, content, `<a href="other.html">other</a>`)
+	assert.Contains(t, content, `<pre><code class="language-yaml">`)
+}
@@ -23,10 +23,15 @@ package generator
 
 import (
 	"fmt"
+	"path"
 	"sort"
 	"strconv"
+	"strings"
 
+	"github.com/matty-rose/gha-docs/pkg/analysis"
 	"github.com/matty-rose/gha-docs/pkg/document"
+	"github.com/matty-rose/gha-docs/pkg/expression"
+	"github.com/matty-rose/gha-docs/pkg/resolver"
 	"github.com/matty-rose/gha-docs/pkg/types"
 )
 
@@ -34,104 +39,404 @@ type markdownGenerator struct {
 	config Config
 }
 
-func (mdg markdownGenerator) Generate(action *types.CompositeAction) string {
+func (mdg markdownGenerator) Generate(action *types.CompositeAction) (string, error) {
 	doc := document.NewMarkdownDocument()
+	if mdg.config.HeadingLevel > 1 {
+		doc.SetHeadingOffset(mdg.config.HeadingLevel - 1)
+	}
 
-	doc.WriteHeading(action.Name, 1)
-	doc.WriteTextLn(action.Description)
+	if !mdg.config.OmitHeader {
+		doc.WriteHeading(action.Name, 1)
+		doc.WriteTextLn(action.Description)
+	}
 
-	doc.WriteNewLine()
-	doc.WriteHeading("Inputs", 2)
+	body := doc.NewSection()
+	if err := mdg.generateSections(action, body); err != nil {
+		return "", err
+	}
 
-	if len(action.Inputs) != 0 {
-		mdg.generateInputTable(action, doc)
-	} else {
-		doc.WriteTextLn("No inputs.")
+	if mdg.config.TableOfContents {
+		if !mdg.config.OmitHeader {
+			doc.WriteNewLine()
+		}
+
+		doc.WriteTableOfContents(body.Headings())
 	}
 
-	doc.WriteNewLine()
-	doc.WriteHeading("Outputs", 2)
+	doc.WriteDocument(body)
 
-	if len(action.Outputs) != 0 {
-		mdg.generateOutputTable(action, doc)
-	} else {
-		doc.WriteTextLn("No outputs.")
+	return doc.Render(), nil
+}
+
+func (mdg markdownGenerator) generateSections(action *types.CompositeAction, doc *document.MarkdownDocument) error {
+	for idx, section := range mdg.config.sections() {
+		// Separate each section from the content before it, unless the section starts the document.
+		if idx != 0 || !mdg.config.OmitHeader || mdg.config.TableOfContents {
+			doc.WriteNewLine()
+		}
+
+		doc.WriteHeading(mdg.config.sectionTitle(section), 2)
+
+		switch section {
+		case InputsSection:
+			mdg.generateSection(section, len(action.Inputs) != 0, doc, func() {
+				mdg.generateInputTable(action, doc)
+			})
+		case OutputsSection:
+			mdg.generateSection(section, len(action.Outputs) != 0, doc, func() {
+				mdg.generateOutputTable(action, doc)
+			})
+		case ExternalActionsSection:
+			mdg.generateSection(section, len(action.Uses) != 0, doc, func() {
+				mdg.generateExternalActionTable(action, doc)
+			})
+			mdg.generateTransitiveExternalActions(action, doc)
+		case StepsSection:
+			mdg.generateSection(section, len(action.Steps) != 0, doc, func() {
+				mdg.generateSteps(action, doc)
+			})
+		case WarningsSection:
+			findings := analysis.Analyze(action)
+			mdg.generateSection(section, len(findings) != 0, doc, func() {
+				mdg.generateWarnings(findings, doc)
+			})
+		case ExampleUsageSection:
+			if err := mdg.generateExampleUsageBlock(action, doc); err != nil {
+				return err
+			}
+		}
 	}
 
-	doc.WriteNewLine()
-	doc.WriteHeading("External Actions", 2)
+	return nil
+}
 
-	if len(action.Uses) != 0 {
-		mdg.generateExternalActionTable(action, doc)
+// generateSection writes the content of a section if it has anything to list, or its empty state text otherwise.
+func (mdg markdownGenerator) generateSection(
+	section Section,
+	hasContent bool,
+	doc *document.MarkdownDocument,
+	generateContent func(),
+) {
+	if hasContent {
+		generateContent()
 	} else {
-		doc.WriteTextLn("No external actions.")
+		doc.WriteTextLn(mdg.config.sectionEmptyText(section))
+	}
+}
+
+func (mdg markdownGenerator) generateTransitiveExternalActions(
+	action *types.CompositeAction,
+	doc *document.MarkdownDocument,
+) {
+	if !mdg.config.TransitiveExternalActions {
+		return
 	}
 
 	doc.WriteNewLine()
-	doc.WriteHeading("Example Usage", 2)
-	mdg.generateExampleUsageBlock(action, doc)
+	doc.WriteHeading("Transitive External Actions", 3)
 
-	return doc.Render()
+	if len(action.TransitiveUses) != 0 {
+		mdg.generateTransitiveExternalActionTable(action, doc)
+	} else {
+		doc.WriteTextLn("No transitive external actions.")
+	}
 }
 
 func (mdg markdownGenerator) generateInputTable(act *types.CompositeAction, doc *document.MarkdownDocument) {
-	columns := []string{"Name", "Description", "Required", "Default"}
+	securityNotes := map[string]string{}
+	for _, risk := range analysis.InjectionRisks(act) {
+		securityNotes[risk.Input] = formatInjectionRisk(act, risk)
+	}
 
-	var rows [][]string
+	typed := false
+
+	for _, inp := range act.Inputs {
+		if inp.Type != "" {
+			typed = true
+			break
+		}
+	}
+
+	// Only show types and security notes when an input has any.
+	available := map[string]bool{
+		"name":           true,
+		"description":    true,
+		"type":           typed,
+		"required":       true,
+		"default":        true,
+		"security-notes": len(securityNotes) != 0,
+	}
+
+	var rows []map[string]string
 
 	sort.Slice(act.Inputs, func(a, b int) bool {
 		return act.Inputs[a].Name < act.Inputs[b].Name
 	})
 
 	for _, inp := range act.Inputs {
-		rows = append(
-			rows,
-			[]string{
-				inp.Name,
-				inp.Description,
-				strconv.FormatBool(inp.Required),
-				doc.FormatCode(inp.Default),
-			},
-		)
+		name := inp.Name
+		if mdg.config.showsArguments() {
+			// Anchor the row so arguments passed to external actions can link to it.
+			name = doc.CreateAnchor(inputAnchor(inp.Name)) + name
+		}
+
+		rows = append(rows, map[string]string{
+			"name":           name,
+			"description":    inp.Description,
+			"type":           formatInputType(inp, doc),
+			"required":       strconv.FormatBool(inp.Required),
+			"default":        formatDefault(inp.Default, doc),
+			"security-notes": securityNotes[inp.Name],
+		})
 	}
 
-	_, _ = doc.WriteTable(columns, rows)
+	writeTable(doc, inputColumns, mdg.config.InputColumns, available, rows)
+}
+
+// formatInputType formats the declared type of an input, listing the choices of a choice input.
+func formatInputType(inp types.Input, doc *document.MarkdownDocument) string {
+	if inp.Type != types.ChoiceInput {
+		return string(inp.Type)
+	}
+
+	choices := make([]string, 0, len(inp.Choices))
+	for _, choice := range inp.Choices {
+		choices = append(choices, doc.FormatCode(choice))
+	}
+
+	return fmt.Sprintf("one of %s", strings.Join(choices, ", "))
+}
+
+// formatDefault formats the default value of an input, explaining defaults which are expressions referring to well
+// known contexts, such as github.token.
+func formatDefault(value string, doc *document.MarkdownDocument) string {
+	formatted := doc.FormatCode(value)
+
+	expressions := expression.FindAll(value)
+	if len(expressions) == 1 && expressions[0].Err == nil && expressions[0].Raw == strings.TrimSpace(value) {
+		if description, ok := expression.Describe(expressions[0].Node); ok {
+			formatted = fmt.Sprintf("%s (%s)", formatted, description)
+		}
+	}
+
+	return formatted
+}
+
+// formatInjectionRisk describes the steps an input is interpolated into, warning against passing it untrusted data.
+func formatInjectionRisk(act *types.CompositeAction, risk analysis.InjectionRisk) string {
+	steps := make([]string, 0, len(risk.Steps))
+	for _, idx := range risk.Steps {
+		steps = append(steps, fmt.Sprintf("%d (%s)", idx+1, act.Steps[idx].DisplayName()))
+	}
+
+	noun := "step"
+	if len(steps) > 1 {
+		noun = "steps"
+	}
+
+	return fmt.Sprintf(
+		"Interpolated directly into the script of %s %s - don't pass untrusted data such as issue or PR titles.",
+		noun,
+		strings.Join(steps, ", "),
+	)
 }
 
 func (mdg markdownGenerator) generateOutputTable(act *types.CompositeAction, doc *document.MarkdownDocument) {
-	columns := []string{"Name", "Description", "Value"}
+	available := map[string]bool{"name": true, "description": true, "value": true}
 
-	var rows [][]string
+	var rows []map[string]string
 
 	sort.Slice(act.Outputs, func(a, b int) bool {
 		return act.Outputs[a].Name < act.Outputs[b].Name
 	})
 
 	for _, out := range act.Outputs {
-		rows = append(rows, []string{out.Name, out.Description, doc.FormatCode(out.Value)})
+		rows = append(rows, map[string]string{
+			"name":        out.Name,
+			"description": out.Description,
+			"value":       doc.FormatCode(out.Value),
+		})
 	}
 
-	_, _ = doc.WriteTable(columns, rows)
+	writeTable(doc, outputColumns, mdg.config.OutputColumns, available, rows)
 }
 
 func (mdg markdownGenerator) generateExternalActionTable(act *types.CompositeAction, doc *document.MarkdownDocument) {
-	columns := []string{"Name", "Creator", "Version", "Step Name", "Step ID"}
+	// Only show upstream descriptions and whether inputs are valid when there is metadata for them.
+	resolved := false
 
-	var rows [][]string
+	for _, ext := range act.Uses {
+		if ext.Resolved != nil {
+			resolved = true
+			break
+		}
+	}
+
+	available := map[string]bool{
+		"name":         true,
+		"description":  resolved,
+		"creator":      true,
+		"version":      true,
+		"step-name":    true,
+		"step-id":      true,
+		"inputs-valid": resolved,
+		"arguments":    mdg.config.showsArguments(),
+	}
+
+	var rows []map[string]string
 
 	sort.Slice(act.Uses, func(a, b int) bool {
 		return act.Uses[a].Name < act.Uses[b].Name
 	})
 
-	for _, act := range act.Uses {
+	inputs := make(map[string]struct{}, len(act.Inputs))
+	for _, inp := range act.Inputs {
+		inputs[inp.Name] = struct{}{}
+	}
+
+	for _, ext := range act.Uses {
+		var description string
+		if ext.Resolved != nil {
+			description = ext.Resolved.Description
+		}
+
+		rows = append(rows, map[string]string{
+			"name":         doc.CreateLink(ext.Name, mdg.getExternalActionLink(ext)),
+			"description":  description,
+			"creator":      ext.Creator,
+			"version":      ext.Version,
+			"step-name":    ext.StepName,
+			"step-id":      ext.StepID,
+			"inputs-valid": formatInputValidation(ext),
+			"arguments":    formatArguments(ext, inputs, doc),
+		})
+	}
+
+	writeTable(doc, externalActionColumns, mdg.config.ExternalActionColumns, available, rows)
+}
+
+func formatInputValidation(ext types.ExternalAction) string {
+	if ext.Resolved == nil {
+		return ""
+	}
+
+	problems := resolver.Validate(ext)
+	if len(problems) == 0 {
+		return "Yes"
+	}
+
+	return fmt.Sprintf("No - %s", strings.Join(problems, ", "))
+}
+
+// inputAnchor returns the name of the anchor for an input's row in the inputs table.
+func inputAnchor(name string) string {
+	return fmt.Sprintf("input-%s", name)
+}
+
+// formatArguments formats the inputs and environment variables passed to an external action by its step, linking
+// references to the action's own inputs to their row in the inputs table.
+func formatArguments(ext types.ExternalAction, inputs map[string]struct{}, doc *document.MarkdownDocument) string {
+	var args []string
+
+	for _, key := range sortedKeys(ext.With) {
+		args = append(args, fmt.Sprintf("%s: %s", doc.FormatCode(key), formatArgumentValue(ext.With[key], inputs, doc)))
+	}
+
+	for _, key := range sortedKeys(ext.Env) {
+		args = append(
+			args,
+			fmt.Sprintf("%s: %s", doc.FormatCode("env."+key), formatArgumentValue(ext.Env[key], inputs, doc)),
+		)
+	}
+
+	return strings.Join(args, "<br>")
+}
+
+func formatArgumentValue(value string, inputs map[string]struct{}, doc *document.MarkdownDocument) string {
+	// Table rows can't span multiple lines.
+	value = strings.ReplaceAll(strings.TrimSpace(value), "\n", " ")
+
+	// Text which isn't a link to an input is collected into a single code span, as adjacent code spans would run
+	// into each other.
+	var builder, code strings.Builder
+
+	last := 0
+
+	for _, expr := range expression.FindAll(value) {
+		name, ok := inputReference(expr)
+		if _, declared := inputs[name]; !ok || !declared {
+			continue
+		}
+
+		code.WriteString(value[last:expr.Offset])
+		builder.WriteString(doc.FormatCode(code.String()))
+		builder.WriteString(doc.CreateLink(doc.FormatCode(expr.Raw), "#"+inputAnchor(name)))
+		code.Reset()
+
+		last = expr.Offset + len(expr.Raw)
+	}
+
+	code.WriteString(value[last:])
+	builder.WriteString(doc.FormatCode(code.String()))
+
+	return builder.String()
+}
+
+// inputReference returns the name of the input an expression refers to, if the expression is just a reference to an
+// input of the action.
+func inputReference(expr expression.Expression) (string, bool) {
+	switch expr.Node.(type) {
+	case *expression.PropertyAccess, *expression.IndexAccess:
+	default:
+		return "", false
+	}
+
+	refs := expr.References()
+	if len(refs) != 1 || refs[0].Context != "inputs" || len(refs[0].Path) != 1 {
+		return "", false
+	}
+
+	return refs[0].Path[0], true
+}
+
+func sortedKeys(mapping map[string]string) []string {
+	keys := make([]string, 0, len(mapping))
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (mdg markdownGenerator) generateTransitiveExternalActionTable(
+	act *types.CompositeAction,
+	doc *document.MarkdownDocument,
+) {
+	columns := []string{"Name", "Creator", "Version", "Used Via"}
+
+	var rows [][]string
+
+	for _, ext := range act.TransitiveUses {
+		via := "Direct"
+
+		if len(ext.Via) != 0 {
+			var refs []string
+			for _, ref := range ext.Via {
+				refs = append(refs, doc.FormatCode(ref))
+			}
+
+			via = strings.Join(refs, " → ")
+		}
+
 		rows = append(
 			rows,
 			[]string{
-				doc.CreateLink(act.Name, act.GetLink()),
-				act.Creator,
-				act.Version,
-				act.StepName,
-				act.StepID,
+				doc.CreateLink(ext.Name, ext.GetLink()),
+				ext.Creator,
+				ext.Version,
+				via,
 			},
 		)
 	}
@@ -139,37 +444,114 @@ func (mdg markdownGenerator) generateExternalActionTable(act *types.CompositeAct
 	_, _ = doc.WriteTable(columns, rows)
 }
 
-func (mdg markdownGenerator) generateExampleUsageBlock(act *types.CompositeAction, doc *document.MarkdownDocument) {
-	doc.WriteCodeBlockMarkerWithFormat("yaml")
-	doc.WriteTextLn(fmt.Sprintf("- name: %s", act.Name))
-
-	switch *mdg.config.ExampleUsageMode {
-	case Remote:
-		// TODO: Some way of getting actual owner/repo name here?
-		doc.WriteTextLn("  uses: owner/repo@latest")
-	case Local:
-		doc.WriteTextLn("  uses: ./path/to/action.yml")
+func (mdg markdownGenerator) getExternalActionLink(ext types.ExternalAction) string {
+	if ext.Local {
+		if link, ok := mdg.config.LocalActionLinks[path.Clean(*ext.LocalPath)]; ok {
+			return link
+		}
 	}
 
-	if len(act.Inputs) == 0 {
-		doc.WriteCodeBlockMarker()
-		return
-	}
+	return ext.GetLink()
+}
 
-	doc.WriteTextLn("  with:")
+// shellCodeFormats maps the shells steps can use to the format used to highlight their scripts.
+var shellCodeFormats = map[string]string{
+	"bash":       "bash",
+	"sh":         "sh",
+	"pwsh":       "powershell",
+	"powershell": "powershell",
+	"python":     "python",
+	"cmd":        "batch",
+}
 
-	sort.Slice(act.Inputs, func(a, b int) bool {
-		return act.Inputs[a].Name < act.Inputs[b].Name
-	})
+func (mdg markdownGenerator) generateSteps(act *types.CompositeAction, doc *document.MarkdownDocument) {
+	for idx, step := range act.Steps {
+		if idx != 0 {
+			doc.WriteNewLine()
+		}
 
-	for idx, inp := range act.Inputs {
-		doc.WriteTextLn(fmt.Sprintf("    # %s", inp.Description))
-		doc.WriteTextLn(fmt.Sprintf("    %s:", inp.Name))
+		doc.WriteHeading(fmt.Sprintf("%d. %s", idx+1, step.DisplayName()), 3)
+
+		properties := []struct {
+			name  string
+			value string
+		}{
+			{"ID", step.ID},
+			{"Uses", step.Uses},
+			{"If", step.If},
+			{"Shell", step.Shell},
+			{"Working Directory", step.WorkingDirectory},
+			{"Continue On Error", step.ContinueOnError},
+		}
 
-		if idx != len(act.Inputs)-1 {
+		for _, property := range properties {
+			if property.value != "" {
+				doc.WriteTextLn(fmt.Sprintf("- %s: %s", property.name, doc.FormatCode(property.value)))
+			}
+		}
+
+		if len(step.Env) != 0 {
+			doc.WriteTextLn("- Env:")
+
+			for _, key := range sortedKeys(step.Env) {
+				doc.WriteTextLn(fmt.Sprintf("  - %s: %s", doc.FormatCode(key), doc.FormatCode(step.Env[key])))
+			}
+		}
+
+		if step.Run != "" {
 			doc.WriteNewLine()
+			doc.WriteTextLn("<details>")
+			doc.WriteTextLn("<summary>Script</summary>")
+			doc.WriteNewLine()
+			doc.WriteCodeBlock(shellCodeFormats[step.Shell], step.Run)
+			doc.WriteNewLine()
+			doc.WriteTextLn("</details>")
 		}
 	}
+}
+
+func (mdg markdownGenerator) generateWarnings(findings []analysis.Finding, doc *document.MarkdownDocument) {
+	columns := []string{"Step", "Line", "Severity", "Rule", "Message"}
+
+	var rows [][]string
+
+	for _, finding := range findings {
+		line := ""
+		if finding.Line != 0 {
+			line = strconv.Itoa(finding.Line)
+		}
+
+		rows = append(
+			rows,
+			[]string{
+				fmt.Sprintf("%d. %s", finding.Step+1, finding.StepName),
+				line,
+				string(finding.Severity),
+				doc.FormatCode(finding.Rule),
+				finding.Message,
+			},
+		)
+	}
+
+	_, _ = doc.WriteTable(columns, rows)
+}
+
+func (mdg markdownGenerator) generateExampleUsageBlock(
+	act *types.CompositeAction,
+	doc *document.MarkdownDocument,
+) error {
+	lines := mdg.exampleUsage(act)
+	if err := mdg.verifyExample(act, lines); err != nil {
+		return err
+	}
+
+	doc.WriteCodeBlockMarkerWithFormat("yaml")
+
+	for _, line := range lines {
+		doc.WriteTextLn(line)
+	}
 
 	doc.WriteCodeBlockMarker()
+
+	return nil
 }
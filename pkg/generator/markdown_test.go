@@ -27,6 +27,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/matty-rose/gha-docs/pkg/document"
 	"github.com/matty-rose/gha-docs/pkg/generator"
 	"github.com/matty-rose/gha-docs/pkg/types"
 )
@@ -35,6 +36,18 @@ func newMarkdownConfig(mode generator.UsageMode) generator.Config {
 	return generator.Config{Format: "markdown", ExampleUsageMode: &mode}
 }
 
+// generate generates documentation for an action, failing the test if generation fails.
+func generate(t *testing.T, g generator.Generator, action *types.CompositeAction) string {
+	t.Helper()
+
+	content, err := g.Generate(action)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return content
+}
+
 func TestGenerateMarkdownNameDescription(t *testing.T) {
 	g, err := generator.New(newMarkdownConfig(generator.Remote))
 	if err != nil {
@@ -45,7 +58,7 @@ func TestGenerateMarkdownNameDescription(t *testing.T) {
 
 	expected := getMarkdownNameDesc()
 
-	content := g.Generate(&action)
+	content := generate(t, g, &action)
 
 	assert.Equal(t, expected, content)
 }
@@ -76,7 +89,7 @@ func TestGenerateMarkdownInputsRemote(t *testing.T) {
 
 	expected := getMarkdownInputs(generator.Remote)
 
-	content := g.Generate(&action)
+	content := generate(t, g, &action)
 
 	assert.Equal(t, expected, content)
 }
@@ -107,7 +120,7 @@ func TestGenerateMarkdownInputsLocal(t *testing.T) {
 
 	expected := getMarkdownInputs(generator.Local)
 
-	content := g.Generate(&action)
+	content := generate(t, g, &action)
 
 	assert.Equal(t, expected, content)
 }
@@ -137,7 +150,7 @@ func TestGenerateMarkdownOutputs(t *testing.T) {
 
 	expected := getMarkdownOutputs()
 
-	content := g.Generate(&action)
+	content := generate(t, g, &action)
 
 	assert.Equal(t, expected, content)
 }
@@ -168,7 +181,7 @@ func TestGenerateMarkdownExternal(t *testing.T) {
 
 	expected := getMarkdownExternal()
 
-	content := g.Generate(&action)
+	content := generate(t, g, &action)
 
 	assert.Equal(t, expected, content)
 }
@@ -211,11 +224,878 @@ func TestGenerateMarkdownFull(t *testing.T) {
 
 	expected := getMarkdownFull()
 
-	content := g.Generate(&action)
+	content := generate(t, g, &action)
 
 	assert.Equal(t, expected, content)
 }
 
+func TestGenerateMarkdownTransitiveExternal(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.TransitiveExternalActions = true
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		TransitiveUses: []types.TransitiveExternalAction{
+			{
+				ExternalAction: types.ExternalAction{Creator: "actions", Name: "cache", Version: "v2"},
+			},
+			{
+				ExternalAction: types.ExternalAction{Creator: "actions", Name: "checkout", Version: "v2"},
+				Via:            []string{"./.github/actions/build", "./.github/actions/lint"},
+			},
+		},
+	}
+
+	expected := `### Transitive External Actions
+| Name | Creator | Version | Used Via |
+| --- | --- | --- | --- |
+| [cache](https://github.com/actions/cache/tree/v2) | actions | v2 | Direct |
+| [checkout](https://github.com/actions/checkout/tree/v2) | actions | v2 | ` +
+		"`./.github/actions/build` → `./.github/actions/lint`" + ` |
+`
+
+	content := generate(t, g, &action)
+
+	assert.Contains(t, content, expected)
+
+	g, err = generator.New(newMarkdownConfig(generator.Remote))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotContains(t, generate(t, g, &action), "Transitive External Actions")
+}
+
+func TestGenerateMarkdownExternalResolved(t *testing.T) {
+	g, err := generator.New(newMarkdownConfig(generator.Remote))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		Uses: []types.ExternalAction{
+			{
+				Creator:  "actions",
+				Name:     "cache",
+				Version:  "v2",
+				With:     map[string]string{"path": "./", "unknown": "x"},
+				Resolved: &types.CompositeAction{Description: "Caches", Inputs: []types.Input{{Name: "path"}}},
+			},
+			{
+				Creator: "actions",
+				Name:    "checkout",
+				Version: "v2",
+			},
+			{
+				Creator:  "actions",
+				Name:     "setup-python",
+				Version:  "v2",
+				Resolved: &types.CompositeAction{},
+			},
+		},
+	}
+
+	expected := `| Name | Description | Creator | Version | Step Name | Step ID | Inputs Valid |
+| --- | --- | --- | --- | --- | --- | --- |
+| [cache](https://github.com/actions/cache/tree/v2) | Caches | actions | v2 |  |  | No - unknown input unknown |
+| [checkout](https://github.com/actions/checkout/tree/v2) |  | actions | v2 |  |  |  |
+| [setup-python](https://github.com/actions/setup-python/tree/v2) |  | actions | v2 |  |  | Yes |
+`
+
+	assert.Contains(t, generate(t, g, &action), expected)
+}
+
+func TestGenerateMarkdownExternalArguments(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.ExternalActionArguments = true
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		Inputs:      []types.Input{{Name: "dir", Description: "dir"}},
+		Uses: []types.ExternalAction{
+			{
+				Creator: "actions",
+				Name:    "cache",
+				Version: "v2",
+				With: map[string]string{
+					"path": "${{ inputs.dir }}/cache",
+					"key":  "${{ inputs.unknown }}|${{ runner.os }}",
+				},
+				Env: map[string]string{"DIR": "${{inputs.dir}}"},
+			},
+			{
+				Creator: "actions",
+				Name:    "checkout",
+				Version: "v2",
+			},
+		},
+	}
+
+	content := generate(t, g, &action)
+
+	assert.Contains(t, content, "| <a name=\"input-dir\"></a>dir | dir | false |  |\n")
+	assert.Contains(
+		t,
+		content,
+		"| Name | Creator | Version | Step Name | Step ID | Arguments |\n| --- | --- | --- | --- | --- | --- |\n"+
+			"| [cache](https://github.com/actions/cache/tree/v2) | actions | v2 |  |  | "+
+			"`key`: `${{ inputs.unknown }}\\|${{ runner.os }}`<br>"+
+			"`path`: [`${{ inputs.dir }}`](#input-dir)`/cache`<br>"+
+			"`env.DIR`: [`${{inputs.dir}}`](#input-dir) |\n"+
+			"| [checkout](https://github.com/actions/checkout/tree/v2) | actions | v2 |  |  |  |\n",
+	)
+}
+
+func TestGenerateMarkdownSteps(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.Steps = true
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		Steps: []types.Step{
+			{
+				ID:    "build",
+				If:    "success()",
+				Shell: "pwsh",
+				Env:   map[string]string{"B": "b", "A": "a"},
+				Run:   "Write-Output hello\nWrite-Output world\n",
+			},
+			{Name: "Cache", Uses: "actions/cache@v2"},
+			{Uses: "actions/checkout@v2"},
+		},
+	}
+
+	expected := `## Steps
+### 1. Run Write-Output hello
+- ID: ` + "`build`" + `
+- If: ` + "`success()`" + `
+- Shell: ` + "`pwsh`" + `
+- Env:
+  - ` + "`A`: `a`" + `
+  - ` + "`B`: `b`" + `
+
+<details>
+<summary>Script</summary>
+
+` + "```powershell" + `
+Write-Output hello
+Write-Output world
+` + "```" + `
+
+</details>
+
+### 2. Cache
+- Uses: ` + "`actions/cache@v2`" + `
+
+### 3. Run actions/checkout@v2
+- Uses: ` + "`actions/checkout@v2`" + `
+
+## Example Usage
+`
+
+	assert.Contains(t, generate(t, g, &action), expected)
+
+	action.Steps = nil
+	assert.Contains(t, generate(t, g, &action), "## Steps\nNo steps.\n")
+}
+
+func TestGenerateMarkdownStepsScriptWithFence(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.Sections = []generator.Section{generator.StepsSection}
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		Steps: []types.Step{
+			{
+				Name:  "Summary",
+				Shell: "bash",
+				Run:   "cat >> \"$GITHUB_STEP_SUMMARY\" <<'MD'\n```\ndone\n```\nMD\n",
+			},
+		},
+	}
+
+	content := generate(t, g, &action)
+
+	expected := "````bash\ncat >> \"$GITHUB_STEP_SUMMARY\" <<'MD'\n```\ndone\n```\nMD\n````\n"
+	assert.Contains(t, content, expected)
+
+	blocks := document.FindCodeBlocks(content)
+	if assert.Len(t, blocks, 1) {
+		assert.Equal(t, "cat >> \"$GITHUB_STEP_SUMMARY\" <<'MD'\n```\ndone\n```\nMD\n", blocks[0].Content)
+	}
+}
+
+func TestGenerateMarkdownWarnings(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.Warnings = true
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		Steps: []types.Step{
+			{Name: "Greet", Run: "echo ${{ inputs.a || 'b' }}"},
+		},
+	}
+
+	expected := `## Warnings
+| Step | Line | Severity | Rule | Message |
+| --- | --- | --- | --- | --- |
+| 1. Greet |  | error | ` + "`missing-shell`" + ` | run steps in composite actions must set shell |
+| 1. Greet | 1 | warning | ` + "`expression-injection`" + ` | ${{ inputs.a \|\| 'b' }} is interpolated directly ` +
+		`into the script, which allows injection - pass it in through env instead |
+
+## Example Usage
+`
+
+	assert.Contains(t, generate(t, g, &action), expected)
+
+	action.Steps = []types.Step{{Run: "cat x | grep y"}}
+	assert.Contains(
+		t,
+		generate(t, g, &action),
+		"| 1. Run cat x \\| grep y |  | error | `missing-shell` | run steps in composite actions must set shell |\n",
+	)
+
+	action.Steps = []types.Step{{Name: "Greet", Shell: "bash", Run: "echo hello"}}
+	assert.Contains(t, generate(t, g, &action), "## Warnings\nNo warnings.\n")
+}
+
+func TestGenerateMarkdownInputSecurityNotes(t *testing.T) {
+	g, err := generator.New(newMarkdownConfig(generator.Remote))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		Inputs: []types.Input{
+			{Name: "title", Description: "title"},
+			{Name: "safe", Description: "safe"},
+		},
+		Steps: []types.Step{
+			{
+				Name:       "Greet",
+				Shell:      "bash",
+				Run:        "echo ${{ inputs.title }}",
+				References: []types.ContextReference{{Context: "inputs", Path: []string{"title"}, Field: "run"}},
+			},
+			{
+				Name:  "Check",
+				Shell: "bash",
+				Run:   "test -n '${{ inputs.title || inputs.missing }}'",
+				References: []types.ContextReference{
+					{Context: "inputs", Path: []string{"title"}, Field: "run"},
+					{Context: "inputs", Path: []string{"missing"}, Field: "run"},
+				},
+			},
+		},
+	}
+
+	expected := `## Inputs
+| Name | Description | Required | Default | Security Notes |
+| --- | --- | --- | --- | --- |
+| safe | safe | false |  |  |
+| title | title | false |  | Interpolated directly into the script of steps 1 (Greet), 2 (Check) - ` +
+		`don't pass untrusted data such as issue or PR titles. |
+`
+
+	assert.Contains(t, generate(t, g, &action), expected)
+
+	action.Steps = action.Steps[:0]
+	assert.Contains(t, generate(t, g, &action), "| Name | Description | Required | Default |\n")
+}
+
+func TestGenerateMarkdownInputDefaultDescriptions(t *testing.T) {
+	g, err := generator.New(newMarkdownConfig(generator.Remote))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		Inputs: []types.Input{
+			{Name: "a", Description: "a", Default: "${{ github.token }}"},
+			{Name: "b", Description: "b", Default: "${{ github.head_ref || 'main' }}"},
+			{Name: "c", Description: "c", Default: "${{ hashFiles('go.sum') }}"},
+			{Name: "d", Description: "d", Default: "ref-${{ github.sha }}"},
+		},
+	}
+
+	expected := `## Inputs
+| Name | Description | Required | Default |
+| --- | --- | --- | --- |
+| a | a | false | ` + "`${{ github.token }}`" + ` (the workflow's GITHUB_TOKEN) |
+| b | b | false | ` + "`${{ github.head_ref \\|\\| 'main' }}`" +
+		` (the source branch of the pull request, or else "main") |
+| c | c | false | ` + "`${{ hashFiles('go.sum') }}`" + ` |
+| d | d | false | ` + "`ref-${{ github.sha }}`" + ` |
+`
+
+	assert.Contains(t, generate(t, g, &action), expected)
+}
+
+func TestGenerateMarkdownExampleInputs(t *testing.T) {
+	action := types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		Inputs: []types.Input{
+			{Name: "token", Description: "The token to use.", Default: "${{ github.token }}"},
+			{Name: "path", Description: "The path to build.", Required: true},
+			{Name: "debug", Description: "Whether to log debug output.", Default: "false"},
+			{Name: "args", Description: "Extra arguments.", Required: true, Default: "--verbose"},
+		},
+	}
+
+	for mode, expected := range map[generator.ExampleInputsMode]string{
+		generator.AllInputs: `  with:
+    # Extra arguments.
+    args: # Required, Default: --verbose
+
+    # Whether to log debug output.
+    debug: # Default: false
+
+    # The path to build.
+    path: # Required
+
+    # The token to use.
+    token: # Default: ${{ github.token }}
+`,
+		generator.RequiredInputs: `  with:
+    # Extra arguments.
+    args: # Required, Default: --verbose
+
+    # The path to build.
+    path: # Required
+`,
+		generator.DefaultInputs: `  with:
+    # Extra arguments.
+    args: --verbose # Required
+
+    # Whether to log debug output.
+    debug: "false"
+
+    # The path to build.
+    path: # Required
+
+    # The token to use.
+    token: ${{ github.token }}
+`,
+		generator.CommentedInputs: `  with:
+    # Extra arguments.
+    args: --verbose # Required
+
+    # Whether to log debug output.
+    # debug: "false"
+
+    # The path to build.
+    path: # Required
+
+    # The token to use.
+    # token: ${{ github.token }}
+`,
+	} {
+		config := newMarkdownConfig(generator.Remote)
+		config.ExampleInputs = mode
+
+		g, err := generator.New(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, generate(t, g, &action), "  uses: owner/repo@latest\n"+expected+"```\n", mode)
+	}
+}
+
+func TestGenerateMarkdownExampleInputsNoneRequired(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.ExampleInputs = generator.RequiredInputs
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		Inputs:      []types.Input{{Name: "a", Description: "a"}},
+	}
+
+	assert.Contains(t, generate(t, g, &action), "  uses: owner/repo@latest\n```\n")
+}
+
+func TestGenerateMarkdownExampleWrappedDescriptions(t *testing.T) {
+	g, err := generator.New(newMarkdownConfig(generator.Remote))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "test: with a colon",
+		Description: "also test",
+		Inputs: []types.Input{
+			{
+				Name: "a",
+				Description: "The first line of a long description, which goes on and on for long enough that it has " +
+					"to be wrapped.\n\nA second paragraph.\n",
+				Default: "multi\nline",
+			},
+		},
+	}
+
+	expected := `- name: 'test: with a colon'
+  uses: owner/repo@latest
+  with:
+    # The first line of a long description, which goes on and on for long enough
+    # that it has to be wrapped.
+    #
+    # A second paragraph.
+    a: # Default: multi\nline
+`
+
+	assert.Contains(t, generate(t, g, &action), expected)
+}
+
+func TestGenerateMarkdownExampleOutputs(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.ExampleOutputs = true
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "Build & Test",
+		Description: "also test",
+		Inputs:      []types.Input{{Name: "path", Description: "The path.", Required: true}},
+		Outputs: []types.Output{
+			{Name: "digest", Description: "The digest.", Value: "x"},
+			{Name: "artifact-path", Description: "The artifact path.", Value: "y"},
+		},
+	}
+
+	expected := "```yaml" + `
+- name: Build & Test
+  id: build-test
+  uses: owner/repo@latest
+  with:
+    # The path.
+    path: # Required
+
+- name: Use outputs
+  shell: bash
+  env:
+    # The artifact path.
+    ARTIFACT_PATH: ${{ steps.build-test.outputs.artifact-path }}
+    # The digest.
+    DIGEST: ${{ steps.build-test.outputs.digest }}
+  run: |
+    echo "$ARTIFACT_PATH"
+    echo "$DIGEST"
+` + "```"
+
+	assert.Contains(t, generate(t, g, &action), expected)
+
+	action.Outputs = nil
+	assert.NotContains(t, generate(t, g, &action), "id: build-test")
+}
+
+func TestGenerateMarkdownExampleOutputsStepID(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.ExampleOutputs = true
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, id := range map[string]string{
+		"Setup Go":      "setup-go",
+		"  --weird__ ":  "weird__",
+		"1st action":    "action-1st-action",
+		"!!!":           "action",
+		"snake_case_42": "snake_case_42",
+	} {
+		action := types.CompositeAction{
+			Name:    name,
+			Outputs: []types.Output{{Name: "a", Description: "a", Value: "a"}},
+		}
+
+		assert.Contains(t, generate(t, g, &action), fmt.Sprintf("  id: %s\n", id), name)
+	}
+}
+
+func TestGenerateMarkdownTableOfContents(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.TableOfContents = true
+	config.Steps = true
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "Inputs",
+		Description: "also test",
+		Steps:       []types.Step{{Name: "Build", Shell: "bash", Run: "make"}},
+	}
+
+	expected := `# Inputs
+also test
+
+- [Inputs](#inputs-1)
+- [Outputs](#outputs)
+- [External Actions](#external-actions)
+- [Steps](#steps)
+  - [1. Build](#1-build)
+- [Example Usage](#example-usage)
+
+## Inputs
+No inputs.
+`
+
+	assert.Contains(t, generate(t, g, &action), expected)
+}
+
+func TestGenerateMarkdownSections(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.Sections = []generator.Section{generator.ExampleUsageSection, generator.OutputsSection}
+	config.SectionTitles = map[generator.Section]string{generator.ExampleUsageSection: "Usage"}
+	config.SectionEmptyText = map[generator.Section]string{generator.OutputsSection: "This action has no outputs."}
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{Name: "test", Description: "also test"}
+
+	expected := `# test
+also test
+
+## Usage
+` + "```yaml" + `
+- name: test
+  uses: owner/repo@latest
+` + "```" + `
+
+## Outputs
+This action has no outputs.
+`
+
+	assert.Equal(t, expected, generate(t, g, &action))
+}
+
+func TestGenerateMarkdownSectionsIgnoreFlags(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.Sections = []generator.Section{generator.InputsSection}
+	config.Steps = true
+	config.Warnings = true
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{Name: "test", Description: "also test"}
+
+	assert.Equal(t, "# test\nalso test\n\n## Inputs\nNo inputs.\n", generate(t, g, &action))
+}
+
+func TestNewInvalidSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   generator.Config
+		expected string
+	}{
+		{
+			name:     "unknown section",
+			config:   generator.Config{Sections: []generator.Section{"usage"}},
+			expected: `unknown section "usage"`,
+		},
+		{
+			name:     "duplicate section",
+			config:   generator.Config{Sections: []generator.Section{"inputs", "inputs"}},
+			expected: `section "inputs" is included more than once`,
+		},
+		{
+			name:     "unknown section title",
+			config:   generator.Config{SectionTitles: map[generator.Section]string{"usage": "Usage"}},
+			expected: `unknown section "usage"`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.config.Format = "markdown"
+
+			_, err := generator.New(test.config)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestGenerateMarkdownHeadingLevel(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.HeadingLevel = 2
+	config.Sections = []generator.Section{generator.InputsSection}
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{Name: "test", Description: "also test"}
+
+	assert.Equal(t, "## test\nalso test\n\n### Inputs\nNo inputs.\n", generate(t, g, &action))
+}
+
+func TestGenerateMarkdownOmitHeader(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.OmitHeader = true
+	config.Sections = []generator.Section{generator.InputsSection, generator.OutputsSection}
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{Name: "test", Description: "also test"}
+
+	assert.Equal(t, "## Inputs\nNo inputs.\n\n## Outputs\nNo outputs.\n", generate(t, g, &action))
+
+	config.TableOfContents = true
+
+	g, err = generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `- [Inputs](#inputs)
+- [Outputs](#outputs)
+
+## Inputs
+No inputs.
+`
+
+	assert.Contains(t, generate(t, g, &action), expected)
+}
+
+func TestGenerateMarkdownDeepestHeadingLevel(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.HeadingLevel = generator.MaxHeadingLevel
+	config.Sections = []generator.Section{generator.StepsSection}
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{Name: "test", Steps: []types.Step{{Name: "Build", Run: "make"}}}
+
+	content := generate(t, g, &action)
+
+	assert.Contains(t, content, "#### test\n")
+	assert.Contains(t, content, "##### Steps\n")
+	assert.Contains(t, content, "###### 1. Build\n")
+}
+
+func TestNewInvalidHeadingLevel(t *testing.T) {
+	_, err := generator.New(generator.Config{Format: "markdown", HeadingLevel: 5})
+	assert.EqualError(t, err, "heading level must be between 1 and 4, got 5")
+
+	_, err = generator.New(generator.Config{Format: "markdown", HeadingLevel: 7})
+	assert.EqualError(t, err, "heading level must be between 1 and 4, got 7")
+
+	_, err = generator.New(generator.Config{Format: "markdown", HeadingLevel: -1})
+	assert.EqualError(t, err, "heading level must be between 1 and 4, got -1")
+}
+
+func TestGenerateMarkdownColumns(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.Sections = []generator.Section{generator.InputsSection, generator.OutputsSection}
+	config.InputColumns = []generator.Column{
+		{ID: "required", Header: "Required?", Format: generator.CheckFormat},
+		{ID: "name"},
+		{ID: "security-notes"},
+	}
+	config.OutputColumns = []generator.Column{{ID: "name", Header: "Output"}}
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		Inputs: []types.Input{
+			{Name: "a", Required: false},
+			{Name: "b", Required: true},
+		},
+		Outputs: []types.Output{{Name: "c", Value: "c"}},
+	}
+
+	expected := `# test
+also test
+
+## Inputs
+| Required? | Name | Security Notes |
+| --- | --- | --- |
+| ❌ | a |  |
+| ✅ | b |  |
+
+## Outputs
+| Output |
+| --- |
+| c |
+`
+
+	assert.Equal(t, expected, generate(t, g, &action))
+}
+
+func TestGenerateMarkdownInputTypes(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.Sections = []generator.Section{generator.InputsSection}
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		Inputs: []types.Input{
+			{Name: "debug", Description: "debug", Type: types.BooleanInput},
+			{Name: "level", Description: "level", Type: types.ChoiceInput, Choices: []string{"debug", "info"}},
+			{Name: "name", Description: "name"},
+		},
+	}
+
+	expected := `# test
+also test
+
+## Inputs
+| Name | Description | Type | Required | Default |
+| --- | --- | --- | --- | --- |
+| debug | debug | boolean | false |  |
+| level | level | one of ` + "`debug`, `info`" + ` | false |  |
+| name | name |  | false |  |
+`
+
+	assert.Equal(t, expected, generate(t, g, &action))
+}
+
+func TestGenerateMarkdownConfiguredColumnsAlwaysShown(t *testing.T) {
+	config := newMarkdownConfig(generator.Remote)
+	config.Sections = []generator.Section{generator.InputsSection, generator.ExternalActionsSection}
+	config.InputColumns = []generator.Column{{ID: "type"}}
+	config.ExternalActionColumns = []generator.Column{{ID: "name"}, {ID: "arguments"}}
+
+	g, err := generator.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	action := types.CompositeAction{
+		Name:        "test",
+		Description: "also test",
+		Inputs:      []types.Input{{Name: "a"}},
+		Uses: []types.ExternalAction{
+			{Name: "checkout", Creator: "actions", Version: "v2", With: map[string]string{"ref": "${{ inputs.a }}"}},
+		},
+	}
+
+	expected := `## Inputs
+| Type |
+| --- |
+|  |
+
+## External Actions
+| Name | Arguments |
+| --- | --- |
+| [checkout](https://github.com/actions/checkout/tree/v2) | ` + "`ref`: [`${{ inputs.a }}`](#input-a)" + ` |
+`
+
+	assert.Contains(t, generate(t, g, &action), expected)
+}
+
+func TestNewInvalidColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   generator.Config
+		expected string
+	}{
+		{
+			name:     "unknown column",
+			config:   generator.Config{InputColumns: []generator.Column{{ID: "value"}}},
+			expected: `unknown inputs table column "value"`,
+		},
+		{
+			name:     "duplicate column",
+			config:   generator.Config{OutputColumns: []generator.Column{{ID: "name"}, {ID: "name"}}},
+			expected: `outputs table column "name" is included more than once`,
+		},
+		{
+			name:     "unknown format",
+			config:   generator.Config{InputColumns: []generator.Column{{ID: "required", Format: "emoji"}}},
+			expected: `unknown format "emoji" for inputs table column "required"`,
+		},
+		{
+			name:     "format of non-boolean column",
+			config:   generator.Config{ExternalActionColumns: []generator.Column{{ID: "version", Format: "check"}}},
+			expected: `external actions table column "version" can't be formatted`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			test.config.Format = "markdown"
+
+			_, err := generator.New(test.config)
+			assert.EqualError(t, err, test.expected)
+		})
+	}
+}
+
 func getUsageModeOutputString(mode generator.UsageMode) string {
 	switch mode {
 	case generator.Remote:
@@ -270,10 +1150,10 @@ No external actions.
   uses: %s
   with:
     # a
-    a:
+    a: # Default: a
 
     # b
-    b:
+    b: # Required
 `+"```"+`
 `, getUsageModeOutputString(mode))
 }
@@ -351,10 +1231,10 @@ No external actions.
   uses: owner/repo@latest
   with:
     # a
-    a:
+    a: # Default: a
 
     # b
-    b:
+    b: # Required
 ` + "```" + `
 `
 }
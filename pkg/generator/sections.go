@@ -0,0 +1,148 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package generator
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Section is a section of the generated documentation.
+type Section string
+
+const (
+	InputsSection          Section = "inputs"
+	OutputsSection         Section = "outputs"
+	ExternalActionsSection Section = "external-actions"
+	StepsSection           Section = "steps"
+	WarningsSection        Section = "warnings"
+	ExampleUsageSection    Section = "example-usage"
+)
+
+// sectionDefaults holds the title and empty state text of each section, in the default order.
+var sectionDefaults = []struct {
+	section   Section
+	title     string
+	emptyText string
+}{
+	{InputsSection, "Inputs", "No inputs."},
+	{OutputsSection, "Outputs", "No outputs."},
+	{ExternalActionsSection, "External Actions", "No external actions."},
+	{StepsSection, "Steps", "No steps."},
+	{WarningsSection, "Warnings", "No warnings."},
+	{ExampleUsageSection, "Example Usage", ""},
+}
+
+// Sections lists every section, in the default order.
+var Sections = func() []Section {
+	sections := make([]Section, 0, len(sectionDefaults))
+	for _, defaults := range sectionDefaults {
+		sections = append(sections, defaults.section)
+	}
+
+	return sections
+}()
+
+func isSection(section Section) bool {
+	for _, s := range Sections {
+		if s == section {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateSections checks that the sections configured are all known, and none are included twice.
+func (c Config) validateSections() error {
+	included := map[Section]bool{}
+
+	for _, section := range c.Sections {
+		if !isSection(section) {
+			return errors.Errorf("unknown section %q", section)
+		}
+
+		if included[section] {
+			return errors.Errorf("section %q is included more than once", section)
+		}
+
+		included[section] = true
+	}
+
+	for _, overrides := range []map[Section]string{c.SectionTitles, c.SectionEmptyText} {
+		for section := range overrides {
+			if !isSection(section) {
+				return errors.Errorf("unknown section %q", section)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sections returns the sections to generate, in order.
+func (c Config) sections() []Section {
+	if len(c.Sections) != 0 {
+		return c.Sections
+	}
+
+	var sections []Section
+
+	for _, section := range Sections {
+		if (section == StepsSection && !c.Steps) || (section == WarningsSection && !c.Warnings) {
+			continue
+		}
+
+		sections = append(sections, section)
+	}
+
+	return sections
+}
+
+// sectionTitle returns the heading of a section.
+func (c Config) sectionTitle(section Section) string {
+	if title, ok := c.SectionTitles[section]; ok {
+		return title
+	}
+
+	for _, defaults := range sectionDefaults {
+		if defaults.section == section {
+			return defaults.title
+		}
+	}
+
+	return string(section)
+}
+
+// sectionEmptyText returns the text shown in a section with nothing to list.
+func (c Config) sectionEmptyText(section Section) string {
+	if text, ok := c.SectionEmptyText[section]; ok {
+		return text
+	}
+
+	for _, defaults := range sectionDefaults {
+		if defaults.section == section {
+			return defaults.emptyText
+		}
+	}
+
+	return ""
+}
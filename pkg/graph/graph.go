@@ -0,0 +1,244 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package graph
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/matty-rose/gha-docs/pkg/parser"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+// Node is a single action in the dependency graph.
+type Node struct {
+	// ID is the directory of a local action relative to the repository root, or the reference of a remote action.
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Local bool   `json:"local"`
+	// File is the action file of a local action.
+	File string `json:"file,omitempty"`
+	// Missing is set for local actions which couldn't be found.
+	Missing bool `json:"missing,omitempty"`
+}
+
+// Edge records that one action uses another in one of its steps.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Graph is the dependency graph of a set of actions, following local actions recursively. Remote actions are leaves
+// of the graph, as their own dependencies aren't resolved.
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+	// Cycles lists each cycle found as the IDs of the actions in it, starting and ending with the same action.
+	Cycles [][]string `json:"cycles"`
+}
+
+// Node returns the node with the given ID, if it is in the graph.
+func (g Graph) Node(id string) (Node, bool) {
+	for _, node := range g.Nodes {
+		if node.ID == id {
+			return node, true
+		}
+	}
+
+	return Node{}, false
+}
+
+// LocalFiles returns the action files of the local actions in the graph which were found, sorted.
+func (g Graph) LocalFiles() []string {
+	var files []string
+
+	for _, node := range g.Nodes {
+		if node.Local && !node.Missing {
+			files = append(files, node.File)
+		}
+	}
+
+	sort.Strings(files)
+
+	return files
+}
+
+const (
+	unvisited = iota
+	visiting
+	visited
+)
+
+type builder struct {
+	root  string
+	nodes map[string]*Node
+	edges map[Edge]struct{}
+	state map[string]int
+	stack []string
+	graph *Graph
+}
+
+// Build returns the dependency graph of the action files. Local action references are resolved relative to the
+// repository root, as GitHub does.
+func Build(root string, actionFiles ...string) (*Graph, error) {
+	b := builder{
+		root:  root,
+		nodes: make(map[string]*Node),
+		edges: make(map[Edge]struct{}),
+		state: make(map[string]int),
+		graph: &Graph{Cycles: [][]string{}},
+	}
+
+	for _, file := range actionFiles {
+		id := b.localID(filepath.Dir(file))
+		b.addNode(Node{ID: id, Local: true, File: file})
+
+		if b.state[id] == unvisited {
+			if err := b.visit(id, file); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, node := range b.nodes {
+		b.graph.Nodes = append(b.graph.Nodes, *node)
+	}
+
+	for edge := range b.edges {
+		b.graph.Edges = append(b.graph.Edges, edge)
+	}
+
+	sort.Slice(b.graph.Nodes, func(i, j int) bool {
+		return b.graph.Nodes[i].ID < b.graph.Nodes[j].ID
+	})
+	sort.Slice(b.graph.Edges, func(i, j int) bool {
+		if b.graph.Edges[i].From != b.graph.Edges[j].From {
+			return b.graph.Edges[i].From < b.graph.Edges[j].From
+		}
+
+		return b.graph.Edges[i].To < b.graph.Edges[j].To
+	})
+
+	return b.graph, nil
+}
+
+// localID returns the ID of the local action in the directory, which is its path relative to the repository root
+// where possible.
+func (b *builder) localID(dir string) string {
+	absRoot, rootErr := filepath.Abs(b.root)
+	absDir, dirErr := filepath.Abs(dir)
+
+	if rootErr != nil || dirErr != nil {
+		return filepath.ToSlash(filepath.Clean(dir))
+	}
+
+	rel, err := filepath.Rel(absRoot, absDir)
+	if err != nil {
+		return filepath.ToSlash(absDir)
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+func (b *builder) addNode(node Node) *Node {
+	if existing, ok := b.nodes[node.ID]; ok {
+		return existing
+	}
+
+	b.nodes[node.ID] = &node
+
+	return &node
+}
+
+func (b *builder) visit(id, file string) error {
+	b.state[id] = visiting
+	b.stack = append(b.stack, id)
+
+	action, err := parser.Parse(file)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("couldn't parse the action file: %s", file))
+	}
+
+	b.nodes[id].Name = action.Name
+
+	for _, ext := range action.Uses {
+		if !ext.Local {
+			child := b.addNode(Node{ID: ext.Reference(), Name: ext.Name})
+			b.edges[Edge{From: id, To: child.ID}] = struct{}{}
+
+			continue
+		}
+
+		if err := b.visitLocal(id, ext); err != nil {
+			return err
+		}
+	}
+
+	b.stack = b.stack[:len(b.stack)-1]
+	b.state[id] = visited
+
+	return nil
+}
+
+func (b *builder) visitLocal(parentID string, ext types.ExternalAction) error {
+	childID := path.Clean(filepath.ToSlash(*ext.LocalPath))
+
+	childFile, found := parser.ResolveLocal(b.root, *ext.LocalPath)
+	if found {
+		childID = b.localID(filepath.Dir(childFile))
+	}
+
+	child := b.addNode(Node{ID: childID, Name: ext.Name, Local: true, File: childFile, Missing: !found})
+	b.edges[Edge{From: parentID, To: child.ID}] = struct{}{}
+
+	switch {
+	case !found:
+		if b.state[childID] == unvisited {
+			logrus.Warnf("couldn't find local action %s used by %s", *ext.LocalPath, parentID)
+		}
+
+		b.state[childID] = visited
+	case b.state[childID] == unvisited:
+		return b.visit(childID, childFile)
+	case b.state[childID] == visiting:
+		b.recordCycle(childID)
+	}
+
+	return nil
+}
+
+// recordCycle records the cycle formed by the action currently being visited using an action further up the stack.
+func (b *builder) recordCycle(id string) {
+	for idx, stackID := range b.stack {
+		if stackID == id {
+			cycle := append(append([]string{}, b.stack[idx:]...), id)
+			b.graph.Cycles = append(b.graph.Cycles, cycle)
+
+			return
+		}
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package graph_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/graph"
+	"github.com/matty-rose/gha-docs/pkg/parser"
+)
+
+func buildTestGraph(t *testing.T) *graph.Graph {
+	t.Helper()
+
+	g, err := graph.Build("./testdata", "./testdata/.github/actions/build/action.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return g
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	g := buildTestGraph(t)
+
+	var ids []string
+	for _, node := range g.Nodes {
+		ids = append(ids, node.ID)
+	}
+
+	assert.Equal(
+		t,
+		[]string{
+			".github/actions/build",
+			".github/actions/lint",
+			".github/actions/missing",
+			".github/actions/test",
+			"actions/checkout@v2",
+			"actions/setup-python@v2",
+		},
+		ids,
+	)
+
+	assert.Equal(
+		t,
+		[]graph.Edge{
+			{From: ".github/actions/build", To: ".github/actions/lint"},
+			{From: ".github/actions/build", To: ".github/actions/test"},
+			{From: ".github/actions/build", To: "actions/checkout@v2"},
+			{From: ".github/actions/lint", To: "actions/checkout@v2"},
+			{From: ".github/actions/lint", To: "actions/setup-python@v2"},
+			{From: ".github/actions/test", To: ".github/actions/build"},
+			{From: ".github/actions/test", To: ".github/actions/missing"},
+		},
+		g.Edges,
+	)
+
+	assert.Equal(t, [][]string{{".github/actions/build", ".github/actions/test", ".github/actions/build"}}, g.Cycles)
+
+	missing, ok := g.Node(".github/actions/missing")
+	assert.True(t, ok)
+	assert.True(t, missing.Missing)
+
+	lint, ok := g.Node(".github/actions/lint")
+	assert.True(t, ok)
+	assert.Equal(t, "lint", lint.Name)
+	assert.False(t, lint.Missing)
+}
+
+func TestLocalFiles(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(
+		t,
+		[]string{
+			"./testdata/.github/actions/build/action.yml",
+			"testdata/.github/actions/lint/action.yml",
+			"testdata/.github/actions/test/action.yml",
+		},
+		buildTestGraph(t).LocalFiles(),
+	)
+}
+
+func TestBuildInvalidAction(t *testing.T) {
+	t.Parallel()
+
+	g, err := graph.Build(".", "../parser/testdata/invalid.yaml")
+
+	assert.Nil(t, g)
+	assert.Error(t, err)
+}
+
+func TestRenderDOT(t *testing.T) {
+	t.Parallel()
+
+	content, err := graph.Render(buildTestGraph(t), "dot")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, content, "digraph actions {\n")
+	assert.Contains(t, content, `".github/actions/build" [label="build (.github/actions/build)", shape=box];`)
+	assert.Contains(
+		t,
+		content,
+		`".github/actions/missing" [label="missing (.github/actions/missing)", shape=box, style=dashed];`,
+	)
+	assert.Contains(t, content, `"actions/checkout@v2" [label="actions/checkout@v2", shape=ellipse];`)
+	assert.Contains(t, content, `".github/actions/test" -> ".github/actions/build" [color=red];`)
+	assert.Contains(t, content, `".github/actions/lint" -> "actions/checkout@v2";`)
+}
+
+func TestRenderMermaid(t *testing.T) {
+	t.Parallel()
+
+	content, err := graph.Render(buildTestGraph(t), "mermaid")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, content, "graph LR\n")
+	assert.Contains(t, content, "  n0[\"build (.github/actions/build)\"]\n")
+	assert.Contains(t, content, "  n4([\"actions/checkout@v2\"])\n")
+	assert.Contains(t, content, "  n0 --> n1\n")
+	assert.Contains(t, content, "  linkStyle 1,5 stroke:red\n")
+}
+
+func TestRenderJSON(t *testing.T) {
+	t.Parallel()
+
+	g := buildTestGraph(t)
+
+	content, err := graph.Render(g, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded graph.Graph
+	if err := json.Unmarshal([]byte(content), &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, g.Edges, decoded.Edges)
+	assert.Equal(t, g.Cycles, decoded.Cycles)
+}
+
+func TestRenderInvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := graph.Render(buildTestGraph(t), "invalid")
+
+	assert.Error(t, err)
+}
+
+func TestTransitive(t *testing.T) {
+	t.Parallel()
+
+	action, err := parser.Parse("./testdata/.github/actions/test/action.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transitive, err := graph.Transitive("./testdata", action)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		refs []string
+		vias [][]string
+	)
+
+	for _, ext := range transitive {
+		refs = append(refs, ext.Reference())
+		vias = append(vias, ext.Via)
+	}
+
+	assert.Equal(t, []string{"actions/checkout@v2", "actions/setup-python@v2"}, refs)
+	assert.Equal(
+		t,
+		[][]string{
+			{"./.github/actions/build"},
+			{"./.github/actions/build", "./.github/actions/lint/action.yml"},
+		},
+		vias,
+	)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Formats lists the formats a graph can be rendered in.
+var Formats = []string{"dot", "mermaid", "json"}
+
+// Render renders the graph in one of the supported formats. Edges which are part of a cycle are highlighted.
+func Render(g *Graph, format string) (string, error) {
+	switch format {
+	case "dot":
+		return renderDOT(g), nil
+	case "mermaid":
+		return renderMermaid(g), nil
+	case "json":
+		content, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return "", errors.Wrap(err, "couldn't marshal graph to json")
+		}
+
+		return string(content) + "\n", nil
+	}
+
+	return "", errors.New(fmt.Sprintf("unsupported graph format: %s", format))
+}
+
+// cycleEdges returns the edges which make up the cycles in the graph.
+func (g Graph) cycleEdges() map[Edge]struct{} {
+	edges := make(map[Edge]struct{})
+
+	for _, cycle := range g.Cycles {
+		for idx := 0; idx < len(cycle)-1; idx++ {
+			edges[Edge{From: cycle[idx], To: cycle[idx+1]}] = struct{}{}
+		}
+	}
+
+	return edges
+}
+
+func (n Node) label() string {
+	if n.Name == "" || !n.Local {
+		return n.ID
+	}
+
+	return fmt.Sprintf("%s (%s)", n.Name, n.ID)
+}
+
+func renderDOT(g *Graph) string {
+	builder := new(strings.Builder)
+	cycleEdges := g.cycleEdges()
+
+	builder.WriteString("digraph actions {\n")
+	builder.WriteString("  rankdir=LR;\n")
+
+	for _, node := range g.Nodes {
+		attrs := fmt.Sprintf("label=%q", node.label())
+
+		switch {
+		case node.Missing:
+			attrs += ", shape=box, style=dashed"
+		case node.Local:
+			attrs += ", shape=box"
+		default:
+			attrs += ", shape=ellipse"
+		}
+
+		fmt.Fprintf(builder, "  %q [%s];\n", node.ID, attrs)
+	}
+
+	for _, edge := range g.Edges {
+		if _, ok := cycleEdges[edge]; ok {
+			fmt.Fprintf(builder, "  %q -> %q [color=red];\n", edge.From, edge.To)
+			continue
+		}
+
+		fmt.Fprintf(builder, "  %q -> %q;\n", edge.From, edge.To)
+	}
+
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+func renderMermaid(g *Graph) string {
+	builder := new(strings.Builder)
+	cycleEdges := g.cycleEdges()
+	ids := make(map[string]string, len(g.Nodes))
+
+	builder.WriteString("graph LR\n")
+
+	for idx, node := range g.Nodes {
+		ids[node.ID] = fmt.Sprintf("n%d", idx)
+		label := strings.ReplaceAll(node.label(), `"`, "#quot;")
+
+		if node.Local {
+			fmt.Fprintf(builder, "  %s[\"%s\"]\n", ids[node.ID], label)
+		} else {
+			fmt.Fprintf(builder, "  %s([\"%s\"])\n", ids[node.ID], label)
+		}
+	}
+
+	var cycleLinks []string
+
+	for idx, edge := range g.Edges {
+		fmt.Fprintf(builder, "  %s --> %s\n", ids[edge.From], ids[edge.To])
+
+		if _, ok := cycleEdges[edge]; ok {
+			cycleLinks = append(cycleLinks, fmt.Sprint(idx))
+		}
+	}
+
+	if len(cycleLinks) != 0 {
+		fmt.Fprintf(builder, "  linkStyle %s stroke:red\n", strings.Join(cycleLinks, ","))
+	}
+
+	return builder.String()
+}
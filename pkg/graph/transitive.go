@@ -0,0 +1,94 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package graph
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/matty-rose/gha-docs/pkg/parser"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+// Transitive returns every remote action used by the action, either directly or through the local actions it uses,
+// which are resolved relative to the repository root. Each remote action is listed once, along with the shortest
+// chain of local actions it is used through, and the list is sorted by reference.
+func Transitive(root string, action *types.CompositeAction) ([]types.TransitiveExternalAction, error) {
+	type queued struct {
+		action *types.CompositeAction
+		via    []string
+	}
+
+	var transitive []types.TransitiveExternalAction
+
+	queue := []queued{{action: action}}
+	seenLocal := make(map[string]struct{})
+	seenRemote := make(map[string]struct{})
+
+	for len(queue) != 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, ext := range current.action.Uses {
+			if !ext.Local {
+				if _, seen := seenRemote[ext.Reference()]; !seen {
+					seenRemote[ext.Reference()] = struct{}{}
+					transitive = append(transitive, types.TransitiveExternalAction{ExternalAction: ext, Via: current.via})
+				}
+
+				continue
+			}
+
+			id := path.Clean(filepath.ToSlash(*ext.LocalPath))
+			if _, seen := seenLocal[id]; seen {
+				continue
+			}
+
+			seenLocal[id] = struct{}{}
+
+			file, found := parser.ResolveLocal(root, *ext.LocalPath)
+			if !found {
+				logrus.Warnf("couldn't find local action %s, skipping its dependencies", *ext.LocalPath)
+				continue
+			}
+
+			nested, err := parser.Parse(file)
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("couldn't parse the action file: %s", file))
+			}
+
+			via := append(append([]string{}, current.via...), *ext.LocalPath)
+			queue = append(queue, queued{action: nested, via: via})
+		}
+	}
+
+	sort.SliceStable(transitive, func(a, b int) bool {
+		return transitive[a].Reference() < transitive[b].Reference()
+	})
+
+	return transitive, nil
+}
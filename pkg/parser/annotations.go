@@ -0,0 +1,149 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+// annotationRegex matches an annotation in a comment line, such as "# @type boolean".
+var annotationRegex = regexp.MustCompile(`^#\s*@(type|choices)\s+(.+)$`)
+
+// inputAnnotations holds the annotations declared in the comments of an input.
+type inputAnnotations struct {
+	inputType string
+	choices   []string
+}
+
+// parseInputAnnotations finds the annotations declared in comments on each input, or any of its keys, by input name.
+func parseInputAnnotations(content []byte) (map[string]inputAnnotations, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshalling yaml data")
+	}
+
+	annotations := map[string]inputAnnotations{}
+
+	inputs := mappingValue(&root, "inputs")
+	if inputs == nil || inputs.Kind != yaml.MappingNode {
+		return annotations, nil
+	}
+
+	for idx := 0; idx+1 < len(inputs.Content); idx += 2 {
+		var annotation inputAnnotations
+
+		for _, comment := range comments(inputs.Content[idx], inputs.Content[idx+1]) {
+			match := annotationRegex.FindStringSubmatch(strings.TrimSpace(comment))
+			if match == nil {
+				continue
+			}
+
+			switch value := strings.TrimSpace(match[2]); match[1] {
+			case "type":
+				annotation.inputType = value
+			case "choices":
+				for _, choice := range strings.Split(value, ",") {
+					annotation.choices = append(annotation.choices, strings.TrimSpace(choice))
+				}
+			}
+		}
+
+		annotations[inputs.Content[idx].Value] = annotation
+	}
+
+	return annotations, nil
+}
+
+// applyInputAnnotations sets the type and choices of an input from its annotations.
+func applyInputAnnotations(input *types.Input, annotation inputAnnotations) error {
+	input.Choices = annotation.choices
+
+	if annotation.inputType == "" {
+		if len(annotation.choices) != 0 {
+			input.Type = types.ChoiceInput
+		}
+
+		return nil
+	}
+
+	for _, inputType := range types.InputTypes {
+		if annotation.inputType == string(inputType) {
+			input.Type = inputType
+		}
+	}
+
+	if input.Type == "" {
+		return errors.Errorf("input %q has unknown type %q", input.Name, annotation.inputType)
+	}
+
+	if input.Type == types.ChoiceInput && len(input.Choices) == 0 {
+		return errors.Errorf("choice input %q doesn't declare its choices", input.Name)
+	}
+
+	if input.Type != types.ChoiceInput && len(input.Choices) != 0 {
+		return errors.Errorf("input %q declares choices, but has type %q", input.Name, input.Type)
+	}
+
+	return nil
+}
+
+// mappingValue returns the value of a key of the mapping in a document, or nil if it doesn't exist.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) != 0 {
+		node = node.Content[0]
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		if node.Content[idx].Value == key {
+			return node.Content[idx+1]
+		}
+	}
+
+	return nil
+}
+
+// comments returns every comment line attached to the given nodes and their descendants.
+func comments(nodes ...*yaml.Node) []string {
+	var lines []string
+
+	for _, node := range nodes {
+		for _, comment := range []string{node.HeadComment, node.LineComment, node.FootComment} {
+			if comment != "" {
+				lines = append(lines, strings.Split(comment, "\n")...)
+			}
+		}
+
+		lines = append(lines, comments(node.Content...)...)
+	}
+
+	return lines
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package parser
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+// actionFileNames are the file names GitHub looks for when an action is referenced by its directory.
+var actionFileNames = map[string]struct{}{"action.yml": {}, "action.yaml": {}}
+
+// skippedDirs are never searched for actions.
+var skippedDirs = map[string]struct{}{".git": {}, "node_modules": {}}
+
+// IsActionFile returns whether the file name is one GitHub recognises as an action metadata file.
+func IsActionFile(path string) bool {
+	_, ok := actionFileNames[filepath.Base(path)]
+	return ok
+}
+
+// Discover returns the paths of all action metadata files found in the directory or any of its subdirectories,
+// sorted.
+func Discover(dir string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if _, skip := skippedDirs[entry.Name()]; skip && entry.IsDir() {
+			return filepath.SkipDir
+		}
+
+		if !entry.IsDir() && IsActionFile(path) {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't search directory for actions")
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// ParseAll parses every action found in the repository directory, returning them sorted by path.
+func ParseAll(repoDir string) ([]types.CatalogEntry, error) {
+	files, err := Discover(repoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]types.CatalogEntry, 0, len(files))
+
+	for _, file := range files {
+		action, err := Parse(file)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("couldn't parse the action file: %s", file))
+		}
+
+		dir, err := filepath.Rel(repoDir, filepath.Dir(file))
+		if err != nil {
+			return nil, errors.Wrap(err, "couldn't resolve action directory")
+		}
+
+		entries = append(entries, types.CatalogEntry{Dir: filepath.ToSlash(dir), File: file, Action: action})
+	}
+
+	return entries, nil
+}
+
+// ResolveLocal returns the action file for a local action reference, resolved relative to the repository root.
+// References can be to either the directory containing the action file, or the action file itself.
+func ResolveLocal(root, uses string) (string, bool) {
+	target := filepath.Join(root, filepath.FromSlash(uses))
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return "", false
+	}
+
+	if !info.IsDir() {
+		return target, IsActionFile(target)
+	}
+
+	for _, name := range []string{"action.yml", "action.yaml"} {
+		file := filepath.Join(target, name)
+		if _, err := os.Stat(file); err == nil {
+			return file, true
+		}
+	}
+
+	return "", false
+}
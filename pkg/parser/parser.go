@@ -22,6 +22,7 @@ THE SOFTWARE.
 package parser
 
 import (
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
 	"regexp"
@@ -40,9 +41,14 @@ func Parse(filename string) (*types.CompositeAction, error) {
 		return nil, errors.Wrap(err, "couldn't read given yaml file")
 	}
 
+	return ParseBytes(file)
+}
+
+// ParseBytes parses the content of an action file.
+func ParseBytes(content []byte) (*types.CompositeAction, error) {
 	data := make(map[interface{}]interface{})
 
-	if err := yaml.Unmarshal(file, &data); err != nil {
+	if err := yaml.Unmarshal(content, &data); err != nil {
 		return nil, errors.Wrap(err, "failed unmarshalling yaml data")
 	}
 
@@ -50,7 +56,12 @@ func Parse(filename string) (*types.CompositeAction, error) {
 
 	parseMetadata(&action, data)
 
-	if err := parseInputs(&action, data); err != nil {
+	annotations, err := parseInputAnnotations(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := parseInputs(&action, data, annotations); err != nil {
 		return nil, err
 	}
 
@@ -58,6 +69,10 @@ func Parse(filename string) (*types.CompositeAction, error) {
 		return nil, err
 	}
 
+	if err := parseSteps(&action, data); err != nil {
+		return nil, err
+	}
+
 	if err := parseExternalActions(&action, data); err != nil {
 		return nil, err
 	}
@@ -66,11 +81,26 @@ func Parse(filename string) (*types.CompositeAction, error) {
 }
 
 func parseMetadata(action *types.CompositeAction, data map[interface{}]interface{}) {
-	action.SetName(data["name"].(string))
-	action.SetDescription(data["description"].(string))
+	if name, ok := data["name"].(string); ok {
+		action.SetName(name)
+	}
+
+	if description, ok := data["description"].(string); ok {
+		action.SetDescription(description)
+	}
+
+	if runs, ok := data["runs"].(map[string]interface{}); ok {
+		if using, ok := runs["using"].(string); ok {
+			action.SetUsing(using)
+		}
+	}
 }
 
-func parseInputs(action *types.CompositeAction, data map[interface{}]interface{}) error {
+func parseInputs(
+	action *types.CompositeAction,
+	data map[interface{}]interface{},
+	annotations map[string]inputAnnotations,
+) error {
 	inputs, ok := data["inputs"].(map[string]interface{})
 	if !ok {
 		logrus.Debug("no inputs found")
@@ -83,6 +113,10 @@ func parseInputs(action *types.CompositeAction, data map[interface{}]interface{}
 			return errors.Wrap(err, "failed parsing action input into struct")
 		}
 
+		if err := applyInputAnnotations(&inp, annotations[name]); err != nil {
+			return err
+		}
+
 		action.AddInput(inp)
 	}
 
@@ -102,6 +136,8 @@ func parseOutputs(action *types.CompositeAction, data map[interface{}]interface{
 			return errors.Wrap(err, "failed parsing action output into struct")
 		}
 
+		out.References = findReferences("value", out.Value, false)
+
 		action.AddOutput(out)
 	}
 
@@ -109,7 +145,7 @@ func parseOutputs(action *types.CompositeAction, data map[interface{}]interface{
 }
 
 func tryMatchRemoteUses(text string) ([][]string, bool) {
-	regex := *regexp.MustCompile(`(.+)\/(.+)@(.+)`)
+	regex := *regexp.MustCompile(`^([^/]+)\/([^/@]+)(?:\/([^@]+))?@(.+)$`)
 
 	res := regex.FindAllStringSubmatch(text, -1)
 	if res != nil {
@@ -123,7 +159,8 @@ func parseUses(ext *types.ExternalAction, uses string) error {
 	if remote, ok := tryMatchRemoteUses(uses); ok {
 		ext.Creator = remote[0][1]
 		ext.Name = remote[0][2]
-		ext.Version = remote[0][3]
+		ext.Path = remote[0][3]
+		ext.Version = remote[0][4]
 		ext.Local = false
 
 		return nil
@@ -139,7 +176,7 @@ func parseUses(ext *types.ExternalAction, uses string) error {
 	return nil
 }
 
-func parseExternalActions(action *types.CompositeAction, data map[interface{}]interface{}) error {
+func getSteps(data map[interface{}]interface{}) []interface{} {
 	runs, ok := data["runs"].(map[string]interface{})
 	if !ok {
 		logrus.Debug("no runs found")
@@ -150,6 +187,63 @@ func parseExternalActions(action *types.CompositeAction, data map[interface{}]in
 		logrus.Debug("no steps found")
 	}
 
+	return steps
+}
+
+func parseSteps(action *types.CompositeAction, data map[interface{}]interface{}) error {
+	for _, s := range getSteps(data) {
+		step, ok := s.(map[string]interface{})
+		if !ok {
+			return errors.New("step does not have a valid structure")
+		}
+
+		parsed := types.Step{
+			Name:             parseString(step["name"]),
+			ID:               parseString(step["id"]),
+			If:               parseString(step["if"]),
+			Shell:            parseString(step["shell"]),
+			WorkingDirectory: parseString(step["working-directory"]),
+			Env:              parseStringMap(step["env"]),
+			Run:              parseString(step["run"]),
+			ContinueOnError:  parseString(step["continue-on-error"]),
+			Uses:             parseString(step["uses"]),
+		}
+		parsed.References = findStepReferences(parsed, parseStringMap(step["with"]))
+
+		action.AddStep(parsed)
+	}
+
+	return nil
+}
+
+// parseString parses a scalar value as a string, returning an empty string if it isn't set.
+func parseString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
+
+// parseStringMap parses a mapping of keys to scalar values, such as the with or env keys of a step, returning nil if
+// the value isn't a mapping.
+func parseStringMap(value interface{}) map[string]string {
+	mapping, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	parsed := make(map[string]string, len(mapping))
+	for key, value := range mapping {
+		parsed[key] = fmt.Sprint(value)
+	}
+
+	return parsed
+}
+
+func parseExternalActions(action *types.CompositeAction, data map[interface{}]interface{}) error {
+	steps := getSteps(data)
+
 	for _, s := range steps {
 		step, ok := s.(map[string]interface{})
 		if !ok {
@@ -171,6 +265,9 @@ func parseExternalActions(action *types.CompositeAction, data map[interface{}]in
 			ext.StepID = stepID
 		}
 
+		ext.With = parseStringMap(step["with"])
+		ext.Env = parseStringMap(step["env"])
+
 		err := parseUses(&ext, step["uses"].(string))
 		if err != nil {
 			return errors.Wrap(err, "couldn't parse the value in the 'uses' field")
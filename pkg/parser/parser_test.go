@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/matty-rose/gha-docs/pkg/parser"
+	"github.com/matty-rose/gha-docs/pkg/types"
 )
 
 func TestParseNameDescription(t *testing.T) {
@@ -63,6 +64,37 @@ func TestParseOutputs(t *testing.T) {
 	assert.Len(t, action.Outputs, 1)
 }
 
+func TestParseInputTypes(t *testing.T) {
+	t.Parallel()
+
+	action, err := parser.Parse("./testdata/input_types.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputTypes := map[string]types.InputType{}
+	choices := map[string][]string{}
+
+	for _, input := range action.Inputs {
+		inputTypes[input.Name] = input.Type
+		choices[input.Name] = input.Choices
+	}
+
+	assert.Equal(
+		t,
+		map[string]types.InputType{
+			"debug":     types.BooleanInput,
+			"retries":   types.NumberInput,
+			"log-level": types.ChoiceInput,
+			"paths":     types.ListInput,
+			"name":      "",
+		},
+		inputTypes,
+	)
+	assert.Equal(t, []string{"debug", "info", "warning"}, choices["log-level"])
+	assert.Empty(t, choices["debug"])
+}
+
 func TestParseUses(t *testing.T) {
 	t.Parallel()
 
@@ -72,6 +104,7 @@ func TestParseUses(t *testing.T) {
 	}
 
 	assert.Len(t, action.Uses, 4)
+	assert.Equal(t, "composite", action.Using)
 }
 
 func TestInvalidFiles(t *testing.T) {
@@ -86,6 +119,7 @@ func TestInvalidFiles(t *testing.T) {
 		{"./testdata/invalid_inputs.yaml", "failed parsing action input into struct"},
 		{"./testdata/invalid_outputs.yaml", "failed parsing action output into struct"},
 		{"./testdata/invalid_uses.yaml", "step does not have a valid structure"},
+		{"./testdata/invalid_input_type.yaml", `input "count" has unknown type "integer"`},
 	}
 
 	for _, tc := range testCases {
@@ -96,3 +130,128 @@ func TestInvalidFiles(t *testing.T) {
 		assert.Contains(t, err.Error(), tc.expectedErrMsg)
 	}
 }
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	files, err := parser.Discover("../site/testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(
+		t,
+		[]string{
+			"../site/testdata/.github/actions/build/action.yml",
+			"../site/testdata/.github/actions/test/action.yaml",
+		},
+		files,
+	)
+}
+
+func TestParseAll(t *testing.T) {
+	t.Parallel()
+
+	entries, err := parser.ParseAll("../site/testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, entries, 2)
+	assert.Equal(t, ".github/actions/build", entries[0].Dir)
+	assert.Equal(t, "build", entries[0].Action.Name)
+	assert.Equal(t, ".github/actions/test", entries[1].Dir)
+	assert.Equal(t, "test", entries[1].Action.Name)
+}
+
+func TestParseUsesPathAndWith(t *testing.T) {
+	t.Parallel()
+
+	action, err := parser.Parse("./testdata/with.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, action.Uses, 1)
+
+	ext := action.Uses[0]
+	assert.Equal(t, "aws-actions", ext.Creator)
+	assert.Equal(t, "amazon-ecr-login", ext.Name)
+	assert.Equal(t, "sub/path", ext.Path)
+	assert.Equal(t, "v1", ext.Version)
+	assert.Equal(t, map[string]string{"registry": "test", "retries": "3"}, ext.With)
+	assert.Equal(t, map[string]string{"AWS_REGION": "${{ inputs.region }}"}, ext.Env)
+	assert.Equal(t, "aws-actions/amazon-ecr-login/sub/path@v1", ext.Reference())
+	assert.Equal(t, "https://github.com/aws-actions/amazon-ecr-login/tree/v1/sub/path", ext.GetLink())
+}
+
+func TestParseSteps(t *testing.T) {
+	t.Parallel()
+
+	action, err := parser.Parse("./testdata/steps.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(
+		t,
+		[]types.Step{
+			{
+				Name:             "Build",
+				ID:               "build",
+				If:               "${{ inputs.build == 'true' }}",
+				Shell:            "bash",
+				WorkingDirectory: "./src",
+				Env:              map[string]string{"TARGET": "${{ inputs.target }}"},
+				Run:              "make build\nmake test\n",
+				ContinueOnError:  "true",
+				References: []types.ContextReference{
+					{Context: "inputs", Path: []string{"build"}, Field: "if", Line: 1, Column: 5},
+					{Context: "inputs", Path: []string{"target"}, Field: "env.TARGET", Line: 1, Column: 5},
+				},
+			},
+			{Uses: "actions/cache@v2"},
+		},
+		action.Steps,
+	)
+	assert.Len(t, action.Uses, 1)
+}
+
+func TestParseReferences(t *testing.T) {
+	t.Parallel()
+
+	action, err := parser.Parse("./testdata/references.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, action.Outputs, 1) {
+		assert.Equal(
+			t,
+			[]types.ContextReference{
+				{Context: "steps", Path: []string{"greet", "outputs", "greeting"}, Field: "value", Line: 1, Column: 5},
+			},
+			action.Outputs[0].References,
+		)
+	}
+
+	if assert.Len(t, action.Steps, 2) {
+		assert.Equal(
+			t,
+			[]types.ContextReference{
+				{Context: "github", Path: []string{"event_name"}, Field: "if", Line: 1, Column: 1},
+				{Context: "inputs", Path: []string{"name"}, Field: "run", Line: 2, Column: 17},
+				{Context: "github", Path: []string{"event", "issue", "title"}, Field: "run", Line: 3, Column: 17},
+			},
+			action.Steps[0].References,
+		)
+		assert.Equal(
+			t,
+			[]types.ContextReference{
+				{Context: "inputs", Path: []string{"ref"}, Field: "with.ref", Line: 1, Column: 5},
+				{Context: "github", Path: []string{"sha"}, Field: "with.ref", Line: 1, Column: 19},
+			},
+			action.Steps[1].References,
+		)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/matty-rose/gha-docs/pkg/expression"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+// findReferences returns the context references made by the expressions in the value of a field. If implicit is set,
+// the value is parsed as a single expression when it has no ${{ }} delimiters, as if conditions are. Expressions which
+// can't be parsed are skipped.
+func findReferences(field, value string, implicit bool) []types.ContextReference {
+	var refs []types.ContextReference
+
+	if implicit && value != "" && !strings.Contains(value, "${{") {
+		node, err := expression.Parse(value)
+		if err != nil {
+			logrus.Warnf("couldn't parse the expression in %s: %s", field, err)
+			return nil
+		}
+
+		for _, ref := range expression.References(node) {
+			refs = append(refs, newContextReference(field, value, ref, ref.Pos))
+		}
+
+		return refs
+	}
+
+	for _, expr := range expression.FindAll(value) {
+		if expr.Err != nil {
+			logrus.Warnf("couldn't parse the expression %s in %s: %s", expr.Raw, field, expr.Err)
+			continue
+		}
+
+		for _, ref := range expr.References() {
+			refs = append(refs, newContextReference(field, value, ref, expr.SourceOffset(ref.Pos)))
+		}
+	}
+
+	return refs
+}
+
+// findMapReferences returns the context references made in each value of a mapping, such as the env of a step, with
+// fields named prefix.KEY.
+func findMapReferences(prefix string, mapping map[string]string) []types.ContextReference {
+	keys := make([]string, 0, len(mapping))
+	for key := range mapping {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var refs []types.ContextReference
+
+	for _, key := range keys {
+		refs = append(refs, findReferences(prefix+"."+key, mapping[key], false)...)
+	}
+
+	return refs
+}
+
+func newContextReference(field, value string, ref expression.Reference, offset int) types.ContextReference {
+	line := strings.Count(value[:offset], "\n") + 1
+	column := offset - strings.LastIndex(value[:offset], "\n")
+
+	return types.ContextReference{
+		Context: ref.Context,
+		Path:    ref.Path,
+		Field:   field,
+		Line:    line,
+		Column:  column,
+	}
+}
+
+// findStepReferences returns the context references made by expressions in a step.
+func findStepReferences(step types.Step, with map[string]string) []types.ContextReference {
+	var refs []types.ContextReference
+
+	refs = append(refs, findReferences("name", step.Name, false)...)
+	refs = append(refs, findReferences("if", step.If, true)...)
+	refs = append(refs, findReferences("working-directory", step.WorkingDirectory, false)...)
+	refs = append(refs, findReferences("continue-on-error", step.ContinueOnError, false)...)
+	refs = append(refs, findMapReferences("env", step.Env)...)
+	refs = append(refs, findMapReferences("with", with)...)
+	refs = append(refs, findReferences("run", step.Run, false)...)
+
+	return refs
+}
@@ -0,0 +1,59 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package resolver
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+type chainResolver struct {
+	resolvers []Resolver
+}
+
+// NewChain returns a resolver which tries each resolver in turn, returning the first metadata found. If no resolver
+// finds the action, the first error other than ErrNotFound is returned, or ErrNotFound if there were none.
+func NewChain(resolvers ...Resolver) Resolver {
+	return chainResolver{resolvers}
+}
+
+func (cr chainResolver) Resolve(ext types.ExternalAction) (*types.CompositeAction, error) {
+	var firstErr error
+
+	for _, r := range cr.resolvers {
+		action, err := r.Resolve(ext)
+		if err == nil {
+			return action, nil
+		}
+
+		if firstErr == nil && errors.Cause(err) != ErrNotFound {
+			firstErr = err
+		}
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return nil, ErrNotFound
+}
@@ -0,0 +1,179 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package resolver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/matty-rose/gha-docs/pkg/parser"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+// DefaultGitHubAPIURL is the base URL of the public GitHub API.
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// GitHubConfig configures the GitHub resolver.
+type GitHubConfig struct {
+	// BaseURL is the base URL of the GitHub API, which defaults to DefaultGitHubAPIURL.
+	BaseURL string
+	// Token authenticates requests to the API, if set.
+	Token string
+	// CacheDir is where fetched action files are cached, laid out as <owner>/<repo>/<ref>/<path>. Caching is
+	// disabled if empty.
+	CacheDir string
+	// Offline stops any requests being made, so actions are only resolved from the cache.
+	Offline bool
+	// Client makes requests to the API, which defaults to a client with a short timeout.
+	Client *http.Client
+}
+
+type githubResolver struct {
+	config GitHubConfig
+	cache  Resolver
+}
+
+// NewGitHub returns a resolver which fetches action files through the GitHub contents API. Fetched files are cached
+// by ref, and the cache is checked before making any requests.
+func NewGitHub(config GitHubConfig) Resolver {
+	if config.BaseURL == "" {
+		config.BaseURL = DefaultGitHubAPIURL
+	}
+
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	gr := githubResolver{config: config}
+	if config.CacheDir != "" {
+		gr.cache = NewLocal(config.CacheDir)
+	}
+
+	return gr
+}
+
+func (gr githubResolver) Resolve(ext types.ExternalAction) (*types.CompositeAction, error) {
+	// The reference is used in the cache path and request URL, neither of which it may escape.
+	if err := checkReference(ext); err != nil {
+		return nil, err
+	}
+
+	if gr.cache != nil {
+		if action, err := gr.cache.Resolve(ext); err == nil {
+			logrus.Debugf("resolved %s from cache", ext.Reference())
+			return action, nil
+		}
+	}
+
+	if gr.config.Offline {
+		return nil, errors.Wrap(ErrNotFound, "offline and not in cache")
+	}
+
+	for _, name := range []string{"action.yml", "action.yaml"} {
+		content, err := gr.fetch(ext, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if content == nil {
+			continue
+		}
+
+		gr.store(ext, name, content)
+
+		return parser.ParseBytes(content)
+	}
+
+	return nil, ErrNotFound
+}
+
+// fetch returns the content of the file in the action's directory, or nil if it doesn't exist.
+func (gr githubResolver) fetch(ext types.ExternalAction, name string) ([]byte, error) {
+	endpoint := fmt.Sprintf(
+		"%s/repos/%s/%s/contents/%s?ref=%s",
+		gr.config.BaseURL,
+		url.PathEscape(ext.Creator),
+		url.PathEscape(ext.Name),
+		path.Join(ext.Path, name),
+		url.QueryEscape(ext.Version),
+	)
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create request")
+	}
+
+	req.Header.Set("Accept", "application/vnd.github.v3.raw")
+
+	if gr.config.Token != "" {
+		req.Header.Set("Authorization", "token "+gr.config.Token)
+	}
+
+	logrus.Debugf("fetching %s", endpoint)
+
+	resp, err := gr.config.Client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't fetch action file")
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, errors.New(fmt.Sprintf("couldn't fetch action file: %s", resp.Status))
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't read action file")
+	}
+
+	return content, nil
+}
+
+// store caches a fetched action file. Failing to cache isn't fatal, as the file has already been fetched.
+func (gr githubResolver) store(ext types.ExternalAction, name string, content []byte) {
+	if gr.config.CacheDir == "" {
+		return
+	}
+
+	dir := filepath.Join(gr.config.CacheDir, ext.Creator, ext.Name, ext.Version, filepath.FromSlash(ext.Path))
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		logrus.Warnf("couldn't create cache directory: %s", err)
+		return
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+		logrus.Warnf("couldn't cache action file: %s", err)
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package resolver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/resolver"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+const upstreamAction = `name: Checkout
+description: Checkout a Git repository at a particular version
+inputs:
+  ref:
+    description: The branch, tag or SHA to checkout.
+runs:
+  using: node12
+  main: dist/index.js
+`
+
+func newGitHubStub(t *testing.T, requests *int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		switch {
+		case r.Header.Get("Authorization") != "token secret":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/repos/actions/checkout/contents/action.yml" && r.URL.Query().Get("ref") == "v2":
+			_, _ = w.Write([]byte(upstreamAction))
+		case r.URL.Path == "/repos/owner/monorepo/contents/sub/action.yaml" && r.URL.Query().Get("ref") == "v1":
+			_, _ = w.Write([]byte("name: Sub\ndescription: A sub action\n"))
+		case r.URL.Path == "/repos/owner/broken/contents/action.yml":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGitHubResolver(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+
+	server := newGitHubStub(t, &requests)
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	r := resolver.NewGitHub(resolver.GitHubConfig{BaseURL: server.URL, Token: "secret", CacheDir: cacheDir})
+
+	checkout := types.ExternalAction{Creator: "actions", Name: "checkout", Version: "v2"}
+
+	action, err := r.Resolve(checkout)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Checkout a Git repository at a particular version", action.Description)
+	assert.FileExists(t, filepath.Join(cacheDir, "actions", "checkout", "v2", "action.yml"))
+
+	action, err = r.Resolve(types.ExternalAction{Creator: "owner", Name: "monorepo", Path: "sub", Version: "v1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Sub", action.Name)
+
+	_, err = r.Resolve(types.ExternalAction{Creator: "owner", Name: "missing", Version: "v1"})
+	assert.Equal(t, resolver.ErrNotFound, errors.Cause(err))
+
+	_, err = r.Resolve(types.ExternalAction{Creator: "owner", Name: "broken", Version: "v1"})
+	assert.Error(t, err)
+	assert.NotEqual(t, resolver.ErrNotFound, errors.Cause(err))
+
+	// Resolving again should be served from the cache.
+	before := atomic.LoadInt32(&requests)
+
+	if _, err := r.Resolve(checkout); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, before, atomic.LoadInt32(&requests))
+}
+
+func TestGitHubResolverOffline(t *testing.T) {
+	t.Parallel()
+
+	cacheDir := t.TempDir()
+	cached := filepath.Join(cacheDir, "actions", "checkout", "v2")
+
+	if err := os.MkdirAll(cached, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(cached, "action.yml"), []byte(upstreamAction), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// No server is running at the base URL, so any request would fail.
+	r := resolver.NewGitHub(resolver.GitHubConfig{BaseURL: "http://127.0.0.1:0", CacheDir: cacheDir, Offline: true})
+
+	action, err := r.Resolve(types.ExternalAction{Creator: "actions", Name: "checkout", Version: "v2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Checkout", action.Name)
+
+	_, err = r.Resolve(types.ExternalAction{Creator: "actions", Name: "checkout", Version: "v3"})
+	assert.Equal(t, resolver.ErrNotFound, errors.Cause(err))
+}
+
+func TestGitHubResolverInvalidReference(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+
+	server := newGitHubStub(t, &requests)
+	defer server.Close()
+
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+	r := resolver.NewGitHub(resolver.GitHubConfig{BaseURL: server.URL, Token: "secret", CacheDir: cacheDir})
+
+	for _, ext := range []types.ExternalAction{
+		{Creator: "actions", Name: "checkout", Version: "../../../escaped"},
+		{Creator: "actions", Name: "checkout", Version: "v2", Path: "sub/../../.."},
+		{Creator: "..", Name: "checkout", Version: "v2"},
+		{Creator: "actions", Name: "checkout", Version: `..\..\escaped`},
+	} {
+		action, err := r.Resolve(ext)
+		assert.Nil(t, action)
+		assert.Equal(t, resolver.ErrInvalidReference, errors.Cause(err))
+	}
+
+	assert.Zero(t, atomic.LoadInt32(&requests))
+	assert.NoDirExists(t, filepath.Join(dir, "escaped"))
+}
+
+func TestChainResolver(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+
+	server := newGitHubStub(t, &requests)
+	defer server.Close()
+
+	r := resolver.NewChain(
+		resolver.NewLocal("./testdata/mirror"),
+		resolver.NewGitHub(resolver.GitHubConfig{BaseURL: server.URL, Token: "secret"}),
+	)
+
+	action, err := r.Resolve(types.ExternalAction{Creator: "actions", Name: "cache", Version: "v2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Cache", action.Name)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+
+	action, err = r.Resolve(types.ExternalAction{Creator: "actions", Name: "checkout", Version: "v2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Checkout", action.Name)
+
+	_, err = r.Resolve(types.ExternalAction{Creator: "owner", Name: "missing", Version: "v1"})
+	assert.Equal(t, resolver.ErrNotFound, errors.Cause(err))
+}
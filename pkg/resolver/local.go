@@ -0,0 +1,59 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package resolver
+
+import (
+	"path/filepath"
+
+	"github.com/matty-rose/gha-docs/pkg/parser"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+type localResolver struct {
+	dir string
+}
+
+// NewLocal returns a resolver which looks up actions in a local directory, such as a vendored mirror of actions or
+// the runner's action cache. Actions are looked for in <dir>/<owner>/<repo>/<ref>/<path>, as the runner lays them
+// out, and then in <dir>/<owner>/<repo>/<path> for mirrors which only hold a single ref of each action.
+func NewLocal(dir string) Resolver {
+	return localResolver{dir}
+}
+
+func (lr localResolver) Resolve(ext types.ExternalAction) (*types.CompositeAction, error) {
+	if err := checkReference(ext); err != nil {
+		return nil, err
+	}
+
+	candidates := []string{
+		filepath.Join(ext.Creator, ext.Name, ext.Version, ext.Path),
+		filepath.Join(ext.Creator, ext.Name, ext.Path),
+	}
+
+	for _, candidate := range candidates {
+		if file, found := parser.ResolveLocal(lr.dir, candidate); found {
+			return parser.Parse(file)
+		}
+	}
+
+	return nil, ErrNotFound
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package resolver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+// ErrNotFound is returned by resolvers which can't find the metadata of an action.
+var ErrNotFound = errors.New("action metadata not found")
+
+// ErrInvalidReference is returned for actions whose reference can't safely be used as a path, such as a ref of
+// "../x", which would resolve outside the directory actions are looked up or cached in.
+var ErrInvalidReference = errors.New("invalid action reference")
+
+// checkReference returns ErrInvalidReference if any part of an action's reference contains a ".." path element.
+func checkReference(ext types.ExternalAction) error {
+	isSeparator := func(r rune) bool {
+		return r == '/' || r == '\\'
+	}
+
+	for _, part := range []string{ext.Creator, ext.Name, ext.Version, ext.Path} {
+		for _, element := range strings.FieldsFunc(part, isSeparator) {
+			if element == ".." {
+				return errors.Wrap(ErrInvalidReference, ext.Reference())
+			}
+		}
+	}
+
+	return nil
+}
+
+// Resolver looks up the metadata of remote actions.
+type Resolver interface {
+	Resolve(ext types.ExternalAction) (*types.CompositeAction, error)
+}
+
+// ResolveAll resolves the metadata of each remote action used by the action. Actions which can't be resolved are
+// skipped, with a warning logged if the resolver failed for any reason other than not finding the action.
+func ResolveAll(r Resolver, action *types.CompositeAction) {
+	for idx, ext := range action.Uses {
+		if ext.Local {
+			continue
+		}
+
+		resolved, err := r.Resolve(ext)
+		if err != nil {
+			if errors.Cause(err) == ErrNotFound {
+				logrus.Debugf("couldn't resolve %s: %s", ext.Reference(), err)
+			} else {
+				logrus.Warnf("couldn't resolve %s: %s", ext.Reference(), err)
+			}
+
+			continue
+		}
+
+		action.Uses[idx].Resolved = resolved
+
+		for _, problem := range Validate(action.Uses[idx]) {
+			logrus.Warnf("step using %s: %s", ext.Reference(), problem)
+		}
+	}
+}
+
+// Validate checks the inputs passed to a resolved action against the inputs it declares, returning a description of
+// each problem found, sorted. Unknown inputs are reported, as are required inputs without a default which aren't
+// passed.
+func Validate(ext types.ExternalAction) []string {
+	if ext.Resolved == nil {
+		return nil
+	}
+
+	var problems []string
+
+	declared := make(map[string]types.Input, len(ext.Resolved.Inputs))
+	for _, inp := range ext.Resolved.Inputs {
+		declared[inp.Name] = inp
+	}
+
+	for key := range ext.With {
+		if _, ok := declared[key]; !ok {
+			problems = append(problems, fmt.Sprintf("unknown input %s", key))
+		}
+	}
+
+	for name, inp := range declared {
+		if _, ok := ext.With[name]; !ok && inp.Required && inp.Default == "" {
+			problems = append(problems, fmt.Sprintf("missing required input %s", name))
+		}
+	}
+
+	sort.Strings(problems)
+
+	return problems
+}
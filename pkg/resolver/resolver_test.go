@@ -0,0 +1,113 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package resolver_test
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/resolver"
+	"github.com/matty-rose/gha-docs/pkg/types"
+)
+
+func TestLocalResolver(t *testing.T) {
+	t.Parallel()
+
+	r := resolver.NewLocal("./testdata/mirror")
+
+	testCases := []struct {
+		ext          types.ExternalAction
+		expectedName string
+	}{
+		{types.ExternalAction{Creator: "actions", Name: "setup-python", Version: "v2"}, "Setup Python"},
+		{types.ExternalAction{Creator: "actions", Name: "cache", Version: "v2"}, "Cache"},
+		{types.ExternalAction{Creator: "owner", Name: "monorepo", Path: "sub", Version: "v1"}, "Sub"},
+	}
+
+	for _, tc := range testCases {
+		action, err := r.Resolve(tc.ext)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, tc.expectedName, action.Name)
+	}
+
+	action, err := r.Resolve(types.ExternalAction{Creator: "actions", Name: "checkout", Version: "v2"})
+	assert.Nil(t, action)
+	assert.Equal(t, resolver.ErrNotFound, errors.Cause(err))
+
+	action, err = r.Resolve(types.ExternalAction{Creator: "actions", Name: "cache", Version: "../../cache/v2"})
+	assert.Nil(t, action)
+	assert.Equal(t, resolver.ErrInvalidReference, errors.Cause(err))
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	resolved := &types.CompositeAction{
+		Inputs: []types.Input{
+			{Name: "optional"},
+			{Name: "required", Required: true},
+			{Name: "required-with-default", Required: true, Default: "x"},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		ext      types.ExternalAction
+		expected []string
+	}{
+		{"unresolved", types.ExternalAction{With: map[string]string{"anything": "x"}}, nil},
+		{"valid", types.ExternalAction{Resolved: resolved, With: map[string]string{"required": "x"}}, nil},
+		{
+			"invalid",
+			types.ExternalAction{Resolved: resolved, With: map[string]string{"optional": "x", "unknown": "x"}},
+			[]string{"missing required input required", "unknown input unknown"},
+		},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, resolver.Validate(tc.ext), tc.name)
+	}
+}
+
+func TestResolveAll(t *testing.T) {
+	t.Parallel()
+
+	local := "./local"
+	action := &types.CompositeAction{
+		Uses: []types.ExternalAction{
+			{Creator: "actions", Name: "cache", Version: "v2"},
+			{Creator: "actions", Name: "checkout", Version: "v2"},
+			{Name: "local", Local: true, LocalPath: &local},
+		},
+	}
+
+	resolver.ResolveAll(resolver.NewLocal("./testdata/mirror"), action)
+
+	assert.NotNil(t, action.Uses[0].Resolved)
+	assert.Nil(t, action.Uses[1].Resolved)
+	assert.Nil(t, action.Uses[2].Resolved)
+}
@@ -0,0 +1,199 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package server
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/matty-rose/gha-docs/pkg/document"
+	"github.com/matty-rose/gha-docs/pkg/generator"
+	"github.com/matty-rose/gha-docs/pkg/parser"
+)
+
+// liveReloadScript reloads the page whenever the server sends an event.
+const liveReloadScript = `new EventSource("/events").onmessage = function() { window.location.reload(); };`
+
+// Server renders generated documentation for a set of action files as HTML. Action files are parsed on every
+// request, so pages always reflect the files on disk, and connected browsers are told to reload by Reload.
+type Server struct {
+	actionFiles []string
+	generator   generator.Generator
+	mux         *http.ServeMux
+
+	mu      sync.Mutex
+	clients map[chan struct{}]struct{}
+}
+
+// New returns a server for the action files, which uses the generator to create the documentation. The generator
+// must generate markdown.
+func New(actionFiles []string, g generator.Generator) *Server {
+	s := &Server{
+		actionFiles: actionFiles,
+		generator:   g,
+		mux:         http.NewServeMux(),
+		clients:     make(map[chan struct{}]struct{}),
+	}
+
+	s.mux.HandleFunc("/", s.handleIndex)
+	s.mux.HandleFunc("/actions/", s.handleAction)
+	s.mux.HandleFunc("/events", s.handleEvents)
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// Reload tells all connected browsers to reload the page.
+func (s *Server) Reload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for client := range s.clients {
+		select {
+		case client <- struct{}{}:
+		default:
+			// A reload is already pending for this client.
+		}
+	}
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if len(s.actionFiles) == 1 {
+		s.renderAction(w, 0)
+		return
+	}
+
+	doc := document.NewMarkdownDocument()
+	doc.WriteHeading("Actions", document.H1)
+
+	for idx, file := range s.actionFiles {
+		title := file
+		if action, err := parser.Parse(file); err == nil {
+			title = action.Name
+		}
+
+		doc.WriteTextLn(fmt.Sprintf(
+			"- %s - %s",
+			doc.CreateLink(title, fmt.Sprintf("/actions/%d", idx)),
+			doc.FormatCode(file),
+		))
+	}
+
+	writePage(w, http.StatusOK, "Actions", document.MarkdownToHTML(doc.Render()))
+}
+
+func (s *Server) handleAction(w http.ResponseWriter, r *http.Request) {
+	idx, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/actions/"))
+	if err != nil || idx < 0 || idx >= len(s.actionFiles) {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.renderAction(w, idx)
+}
+
+func (s *Server) renderAction(w http.ResponseWriter, idx int) {
+	var body strings.Builder
+
+	if len(s.actionFiles) > 1 {
+		body.WriteString("<nav><a href=\"/\">&larr; All actions</a></nav>\n")
+	}
+
+	action, err := parser.Parse(s.actionFiles[idx])
+	if err != nil {
+		writeErrorPage(w, s.actionFiles[idx], &body, errors.Wrap(err, "couldn't parse the action file"))
+		return
+	}
+
+	content, err := s.generator.Generate(action)
+	if err != nil {
+		writeErrorPage(w, s.actionFiles[idx], &body, errors.Wrap(err, "couldn't generate documentation"))
+		return
+	}
+
+	body.WriteString(document.MarkdownToHTML(content))
+	writePage(w, http.StatusOK, action.Name, body.String())
+}
+
+// writeErrorPage writes a page showing an error after the given body, so that the page still reloads when the error
+// is fixed.
+func writeErrorPage(w http.ResponseWriter, title string, body *strings.Builder, err error) {
+	fmt.Fprintf(body, "<h1>Error</h1>\n<pre><code>%s</code></pre>\n", html.EscapeString(err.Error()))
+	writePage(w, http.StatusInternalServerError, title, body.String())
+}
+
+func writePage(w http.ResponseWriter, status int, title, body string) {
+	page := document.HTMLPage{Title: title, Body: body, Scripts: []string{liveReloadScript}}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(page.Render()))
+}
+
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	client := make(chan struct{}, 1)
+
+	s.mu.Lock()
+	s.clients[client] = struct{}{}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, client)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-client:
+			_, _ = w.Write([]byte("data: reload\n\n"))
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package server_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/generator"
+	"github.com/matty-rose/gha-docs/pkg/server"
+)
+
+func newServer(t *testing.T, actionFiles ...string) *server.Server {
+	t.Helper()
+
+	mode := generator.Remote
+
+	g, err := generator.New(generator.Config{Format: "markdown", ExampleUsageMode: &mode})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return server.New(actionFiles, g)
+}
+
+func get(s *server.Server, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return rec
+}
+
+func TestServeSingleAction(t *testing.T) {
+	t.Parallel()
+
+	s := newServer(t, "../parser/testdata/inputs.yaml")
+	rec := get(s, "/")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "<h2 id=\"inputs\">Inputs</h2>")
+	assert.Contains(t, rec.Body.String(), "EventSource")
+	assert.NotContains(t, rec.Body.String(), "All actions")
+}
+
+func TestServeMultipleActions(t *testing.T) {
+	t.Parallel()
+
+	s := newServer(t, "../parser/testdata/inputs.yaml", "../parser/testdata/outputs.yaml")
+
+	rec := get(s, "/")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "href=\"/actions/0\"")
+	assert.Contains(t, rec.Body.String(), "href=\"/actions/1\"")
+
+	rec = get(s, "/actions/1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "<h2 id=\"outputs\">Outputs</h2>")
+	assert.Contains(t, rec.Body.String(), "All actions")
+
+	assert.Equal(t, http.StatusNotFound, get(s, "/actions/2").Code)
+	assert.Equal(t, http.StatusNotFound, get(s, "/missing").Code)
+}
+
+func TestServeInvalidAction(t *testing.T) {
+	t.Parallel()
+
+	rec := get(newServer(t, "../parser/testdata/invalid.yaml"), "/")
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "couldn&#39;t parse the action file")
+}
+
+func TestServeReloadEvents(t *testing.T) {
+	t.Parallel()
+
+	s := newServer(t, "../parser/testdata/inputs.yaml")
+	ts := httptest.NewServer(s)
+
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	s.Reload()
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "data: reload\n", line)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package site
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/matty-rose/gha-docs/pkg/document"
+	"github.com/matty-rose/gha-docs/pkg/generator"
+	"github.com/matty-rose/gha-docs/pkg/parser"
+	"github.com/matty-rose/gha-docs/pkg/types"
+	"github.com/matty-rose/gha-docs/pkg/writer"
+)
+
+const indexFile = "index.html"
+
+// searchScript hides rows of the index table which don't match the search box.
+const searchScript = `document.getElementById("search").addEventListener("input", function(event) {
+  var query = event.target.value.toLowerCase();
+  document.querySelectorAll("tr[data-search]").forEach(function(row) {
+    row.style.display = row.dataset.search.indexOf(query) === -1 ? "none" : "";
+  });
+});`
+
+// pageLinks returns the path of the page for each action, relative to the output directory, by action directory.
+// Directories are flattened into file names, so names which would collide, e.g. for a-b/c and a/b-c, are given a
+// numbered suffix in the order the actions are found. Names are compared case insensitively, as they would collide on
+// case insensitive file systems.
+func pageLinks(entries []types.CatalogEntry) map[string]string {
+	links := make(map[string]string, len(entries))
+	used := map[string]bool{strings.ToLower(indexFile): true}
+
+	for _, entry := range entries {
+		slug := strings.Trim(strings.ReplaceAll(entry.Dir, "/", "-"), ".-")
+		if slug == "" {
+			slug = "root"
+		}
+
+		name := slug + ".html"
+		for idx := 1; used[strings.ToLower(name)]; idx++ {
+			name = fmt.Sprintf("%s-%d.html", slug, idx)
+		}
+
+		used[strings.ToLower(name)] = true
+		links[entry.Dir] = name
+	}
+
+	return links
+}
+
+// Build renders every action found in the repository directory as an HTML page in the output directory, along with
+// an index page linking to them all. References from one action to another local action link to that action's page.
+func Build(repoDir, outDir string, config generator.Config) error {
+	entries, err := parser.ParseAll(repoDir)
+	if err != nil {
+		return err
+	}
+
+	config.Format = "html"
+	config.LocalActionLinks = pageLinks(entries)
+
+	g, err := generator.New(config)
+	if err != nil {
+		return errors.Wrap(err, "couldn't construct the generator")
+	}
+
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return errors.Wrap(err, "couldn't create output directory")
+	}
+
+	for _, entry := range entries {
+		content, err := g.Generate(entry.Action)
+		if err != nil {
+			return errors.Wrapf(err, "couldn't generate documentation for %s", entry.File)
+		}
+
+		page := document.HTMLPage{
+			Title: entry.Action.Name,
+			Body:  fmt.Sprintf("<nav><a href=\"%s\">&larr; All actions</a></nav>\n%s", indexFile, content),
+		}
+
+		if err := writePage(outDir, config.LocalActionLinks[entry.Dir], page); err != nil {
+			return err
+		}
+	}
+
+	return writePage(outDir, indexFile, renderIndex(entries, config.LocalActionLinks))
+}
+
+func renderIndex(entries []types.CatalogEntry, links map[string]string) document.HTMLPage {
+	builder := new(strings.Builder)
+
+	builder.WriteString("<h1>Actions</h1>\n")
+	builder.WriteString("<input type=\"search\" id=\"search\" placeholder=\"Search actions and inputs...\">\n")
+	builder.WriteString("<table>\n<thead>\n<tr><th>Name</th><th>Path</th><th>Description</th></tr>\n</thead>\n")
+	builder.WriteString("<tbody>\n")
+
+	for _, entry := range entries {
+		fmt.Fprintf(
+			builder,
+			"<tr data-search=\"%s\"><td><a href=\"%s\">%s</a></td><td><code>%s</code></td><td>%s</td></tr>\n",
+			html.EscapeString(searchText(entry.Action)),
+			html.EscapeString(links[entry.Dir]),
+			html.EscapeString(entry.Action.Name),
+			html.EscapeString(entry.Dir),
+			html.EscapeString(entry.Action.Description),
+		)
+	}
+
+	builder.WriteString("</tbody>\n</table>\n")
+
+	return document.HTMLPage{Title: "Actions", Body: builder.String(), Scripts: []string{searchScript}}
+}
+
+// searchText is the lowercased text the index search matches against for an action.
+func searchText(action *types.CompositeAction) string {
+	text := []string{action.Name, action.Description}
+
+	for _, inp := range action.Inputs {
+		text = append(text, inp.Name, inp.Description)
+	}
+
+	return strings.ToLower(strings.Join(text, " "))
+}
+
+func writePage(outDir, name string, p document.HTMLPage) error {
+	err := writer.Write(writer.WriteInputs{Content: p.Render(), OutputFile: filepath.Join(outDir, name)})
+
+	return errors.Wrap(err, "couldn't write page")
+}
@@ -0,0 +1,144 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package site_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/generator"
+	"github.com/matty-rose/gha-docs/pkg/site"
+)
+
+func readPage(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+func TestBuildSite(t *testing.T) {
+	t.Parallel()
+
+	outDir := filepath.Join(t.TempDir(), "site")
+	mode := generator.Remote
+
+	if err := site.Build("./testdata", outDir, generator.Config{ExampleUsageMode: &mode}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+
+	assert.Equal(t, []string{"github-actions-build.html", "github-actions-test.html", "index.html"}, names)
+
+	index := readPage(t, outDir, "index.html")
+	assert.Contains(t, index, `<tr data-search="build builds the thing target what to build">`)
+	assert.Contains(t, index, `<a href="github-actions-test.html">test</a>`)
+	assert.Contains(t, index, `id="search"`)
+
+	build := readPage(t, outDir, "github-actions-build.html")
+	assert.Contains(t, build, `<a href="github-actions-test.html">test</a>`)
+	assert.Contains(t, build, `<a href="https://github.com/actions/checkout/tree/v2">checkout</a>`)
+	assert.Contains(t, build, `<a href="index.html">`)
+}
+
+// writeAction writes an action file to a directory of a repository.
+func writeAction(t *testing.T, repoDir, dir, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Join(repoDir, dir), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repoDir, dir, "action.yml"), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuildSiteCollidingPages(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	writeAction(t, repoDir, "a-b/c", "name: first\ndescription: first\nruns:\n  using: composite\n  steps: []\n")
+	writeAction(t, repoDir, "a/b-c", "name: second\ndescription: second\nruns:\n  using: composite\n  steps: []\n")
+
+	outDir := filepath.Join(t.TempDir(), "site")
+	mode := generator.Remote
+
+	if err := site.Build(repoDir, outDir, generator.Config{ExampleUsageMode: &mode}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, readPage(t, outDir, "a-b-c.html"), "<h1 id=\"first\">first</h1>")
+	assert.Contains(t, readPage(t, outDir, "a-b-c-1.html"), "<h1 id=\"second\">second</h1>")
+
+	index := readPage(t, outDir, "index.html")
+	assert.Contains(t, index, `<a href="a-b-c.html">first</a>`)
+	assert.Contains(t, index, `<a href="a-b-c-1.html">second</a>`)
+}
+
+func TestBuildSiteEscapesHTML(t *testing.T) {
+	t.Parallel()
+
+	repoDir := t.TempDir()
+	writeAction(
+		t,
+		repoDir,
+		"evil",
+		"name: <script>alert(1)</script>\ndescription: <script>alert(2)</script>\nruns:\n  using: composite\n"+
+			"  steps: []\n",
+	)
+
+	outDir := filepath.Join(t.TempDir(), "site")
+	mode := generator.Remote
+
+	if err := site.Build(repoDir, outDir, generator.Config{ExampleUsageMode: &mode}); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotContains(t, readPage(t, outDir, "evil.html"), "<script>alert")
+	assert.NotContains(t, readPage(t, outDir, "index.html"), "<script>alert")
+}
+
+func TestBuildSiteMissingDirectory(t *testing.T) {
+	t.Parallel()
+
+	mode := generator.Remote
+	err := site.Build("./testdata/missing", t.TempDir(), generator.Config{ExampleUsageMode: &mode})
+
+	assert.Error(t, err)
+}
@@ -28,6 +28,12 @@ type CompositeAction struct {
 	Inputs      []Input
 	Outputs     []Output
 	Uses        []ExternalAction
+	Steps       []Step
+	// Using is the runtime the action runs with e.g. composite, node16 or docker.
+	Using string
+	// TransitiveUses lists every remote action used by the action, including through local actions. It is only
+	// populated when local actions are resolved.
+	TransitiveUses []TransitiveExternalAction
 }
 
 func (c *CompositeAction) SetName(name string) {
@@ -38,6 +44,10 @@ func (c *CompositeAction) SetDescription(description string) {
 	c.Description = description
 }
 
+func (c *CompositeAction) SetUsing(using string) {
+	c.Using = using
+}
+
 func (c *CompositeAction) AddInput(input Input) {
 	c.Inputs = append(c.Inputs, input)
 }
@@ -46,6 +56,10 @@ func (c *CompositeAction) AddOutput(output Output) {
 	c.Outputs = append(c.Outputs, output)
 }
 
+func (c *CompositeAction) AddStep(s Step) {
+	c.Steps = append(c.Steps, s)
+}
+
 func (c *CompositeAction) AddExternalAction(e ExternalAction) {
 	c.Uses = append(c.Uses, e)
 }
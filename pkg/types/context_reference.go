@@ -0,0 +1,57 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package types
+
+import "strings"
+
+// ContextReference is a reference to a context, such as inputs.name or steps.build.outputs.path, made by an
+// expression in an action.
+type ContextReference struct {
+	// Context is the name of the context referenced, e.g. inputs.
+	Context string
+	// Path is the property path followed within the context, e.g. [name] for inputs.name.
+	Path []string
+	// Field is the key the expression is in, e.g. run, if, env.NAME or with.ref for steps and value for outputs.
+	Field string
+	// Line and Column give the position of the reference within the value of the field, starting from 1.
+	Line   int
+	Column int
+}
+
+func (r ContextReference) String() string {
+	return strings.Join(append([]string{r.Context}, r.Path...), ".")
+}
+
+// Is reports whether the reference is to the given context, followed by at least the given path.
+func (r ContextReference) Is(context string, path ...string) bool {
+	if r.Context != context || len(r.Path) < len(path) {
+		return false
+	}
+
+	for idx, property := range path {
+		if r.Path[idx] != property {
+			return false
+		}
+	}
+
+	return true
+}
@@ -25,13 +25,21 @@ import "fmt"
 
 // ExternalAction represents a single external action that is used by the composite action.
 type ExternalAction struct {
-	Creator   string
-	Name      string
+	Creator string
+	Name    string
+	// Path is the subdirectory of the repository containing a remote action, if it isn't at the root.
+	Path      string
 	Version   string
 	StepName  string
 	StepID    string
 	Local     bool
 	LocalPath *string
+	// With holds the inputs passed to the action by the step.
+	With map[string]string
+	// Env holds the environment variables set for the action by the step.
+	Env map[string]string
+	// Resolved is the metadata of the action itself, when it has been resolved.
+	Resolved *CompositeAction
 }
 
 func (e ExternalAction) GetLink() string {
@@ -39,8 +47,26 @@ func (e ExternalAction) GetLink() string {
 	case true:
 		return *e.LocalPath
 	case false:
-		return fmt.Sprintf("https://github.com/%s/%s/tree/%s", e.Creator, e.Name, e.Version)
+		link := fmt.Sprintf("https://github.com/%s/%s/tree/%s", e.Creator, e.Name, e.Version)
+		if e.Path != "" {
+			link = fmt.Sprintf("%s/%s", link, e.Path)
+		}
+
+		return link
 	default:
 		return ""
 	}
 }
+
+// Reference returns the action as it would be referenced in the uses key of a step.
+func (e ExternalAction) Reference() string {
+	if e.Local {
+		return *e.LocalPath
+	}
+
+	if e.Path != "" {
+		return fmt.Sprintf("%s/%s/%s@%s", e.Creator, e.Name, e.Path, e.Version)
+	}
+
+	return fmt.Sprintf("%s/%s@%s", e.Creator, e.Name, e.Version)
+}
@@ -21,10 +21,30 @@ THE SOFTWARE.
 */
 package types
 
+// InputType is the intended type of an input's value, which is always passed to the action as a string.
+type InputType string
+
+const (
+	StringInput  InputType = "string"
+	BooleanInput InputType = "boolean"
+	NumberInput  InputType = "number"
+	ChoiceInput  InputType = "choice"
+	JSONInput    InputType = "json"
+	// ListInput is a list of values, one per line.
+	ListInput InputType = "list"
+)
+
+// InputTypes lists every input type.
+var InputTypes = []InputType{StringInput, BooleanInput, NumberInput, ChoiceInput, JSONInput, ListInput}
+
 // Input represents a single input to a composite action.
 type Input struct {
 	Name        string
 	Description string `mapstructure:"description"`
 	Required    bool   `mapstructure:"required"`
 	Default     string `mapstructure:"default"`
+	// Type is the intended type of the input, declared by an annotation, or empty if it isn't declared.
+	Type InputType `mapstructure:"-"`
+	// Choices are the values allowed for a choice input.
+	Choices []string `mapstructure:"-"`
 }
@@ -26,4 +26,6 @@ type Output struct {
 	Name        string
 	Description string `mapstructure:"description"`
 	Value       string `mapstructure:"value"`
+	// References are the context references made by expressions in the value.
+	References []ContextReference `mapstructure:"-"`
 }
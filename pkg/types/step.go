@@ -0,0 +1,56 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Step represents a single step of a composite action.
+type Step struct {
+	Name             string
+	ID               string
+	If               string
+	Shell            string
+	WorkingDirectory string
+	Env              map[string]string
+	Run              string
+	// ContinueOnError is kept as a string, as it can be an expression as well as a boolean.
+	ContinueOnError string
+	Uses            string
+	// References are the context references made by expressions in the step.
+	References []ContextReference
+}
+
+// DisplayName returns the name of the step as GitHub displays it, which defaults to the command run or the action
+// used if the step has no name.
+func (s Step) DisplayName() string {
+	switch {
+	case s.Name != "":
+		return s.Name
+	case s.Run != "":
+		return fmt.Sprintf("Run %s", strings.SplitN(strings.TrimSpace(s.Run), "\n", 2)[0])
+	default:
+		return fmt.Sprintf("Run %s", s.Uses)
+	}
+}
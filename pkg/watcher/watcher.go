@@ -0,0 +1,135 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package watcher
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultDebounce is how long to wait for further changes before notifying of a batch of changes.
+const DefaultDebounce = 200 * time.Millisecond
+
+// Watcher notifies of changes to a set of files. The directories containing the files are watched rather than the
+// files themselves, so that changes made by editors which replace the file instead of writing to it are picked up.
+type Watcher struct {
+	fsw      *fsnotify.Watcher
+	files    map[string]struct{}
+	debounce time.Duration
+}
+
+// New returns a watcher for the given files, which is watching from the moment it is returned.
+func New(files []string, debounce time.Duration) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't create file watcher")
+	}
+
+	w := &Watcher{fsw: fsw, files: make(map[string]struct{}), debounce: debounce}
+	dirs := make(map[string]struct{})
+
+	for _, file := range files {
+		abs, err := filepath.Abs(file)
+		if err != nil {
+			fsw.Close()
+			return nil, errors.Wrap(err, "couldn't resolve path to watch")
+		}
+
+		w.files[abs] = struct{}{}
+		dirs[filepath.Dir(abs)] = struct{}{}
+	}
+
+	for dir := range dirs {
+		if err := fsw.Add(dir); err != nil {
+			fsw.Close()
+			return nil, errors.Wrap(err, "couldn't watch directory: "+dir)
+		}
+	}
+
+	return w, nil
+}
+
+// Files returns the absolute paths of the watched files, sorted.
+func (w *Watcher) Files() []string {
+	files := make([]string, 0, len(w.files))
+	for file := range w.files {
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+
+	return files
+}
+
+// Run blocks until the context is cancelled, calling onChange with the sorted list of changed files once no further
+// changes have happened within the debounce period.
+func (w *Watcher) Run(ctx context.Context, onChange func(changed []string)) error {
+	defer w.fsw.Close()
+
+	pending := make(map[string]struct{})
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return nil
+			}
+
+			if _, watched := w.files[filepath.Clean(event.Name)]; !watched || event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			logrus.Debugf("file watcher event: %s", event)
+
+			pending[filepath.Clean(event.Name)] = struct{}{}
+
+			timer.Reset(w.debounce)
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return nil
+			}
+
+			return errors.Wrap(err, "error watching files")
+		case <-timer.C:
+			changed := make([]string, 0, len(pending))
+			for file := range pending {
+				changed = append(changed, file)
+			}
+
+			sort.Strings(changed)
+
+			pending = make(map[string]struct{})
+
+			onChange(changed)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package watcher_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/matty-rose/gha-docs/pkg/watcher"
+)
+
+func TestWatcherDebouncesChanges(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	watched := filepath.Join(dir, "action.yml")
+	unwatched := filepath.Join(dir, "README.md")
+
+	if err := os.WriteFile(watched, []byte("name: test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := watcher.New([]string{watched}, 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	changes := make(chan []string, 10)
+
+	go func() {
+		_ = w.Run(ctx, func(changed []string) { changes <- changed })
+	}()
+
+	for _, content := range []string{"a", "b", "c"} {
+		if err := os.WriteFile(watched, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := os.WriteFile(unwatched, []byte("ignored"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case changed := <-changes:
+		assert.Equal(t, w.Files(), changed)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for change notification")
+	}
+
+	select {
+	case changed := <-changes:
+		t.Fatalf("unexpected extra change notification: %v", changed)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestWatcherMissingDirectory(t *testing.T) {
+	t.Parallel()
+
+	w, err := watcher.New([]string{filepath.Join(t.TempDir(), "missing", "action.yml")}, watcher.DefaultDebounce)
+
+	assert.Nil(t, w)
+	assert.Error(t, err)
+}
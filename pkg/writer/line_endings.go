@@ -0,0 +1,76 @@
+/*
+Copyright © 2021 Matt Rose <matthewrose153@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package writer
+
+import "bytes"
+
+var (
+	lf   = []byte("\n")
+	crlf = []byte("\r\n")
+)
+
+// lineEndings describes the newline conventions used by a file.
+type lineEndings struct {
+	// known is false when there is no content to infer conventions from, in which case content is left as is.
+	known           bool
+	crlf            bool
+	trailingNewline bool
+}
+
+// detectLineEndings infers the newline conventions of some existing content. CRLF is used if the majority of
+// line endings in the content are CRLF.
+func detectLineEndings(content []byte) lineEndings {
+	lines := bytes.Count(content, lf)
+	if lines == 0 {
+		return lineEndings{}
+	}
+
+	crlfLines := bytes.Count(content, crlf)
+
+	return lineEndings{
+		known:           true,
+		crlf:            crlfLines > lines-crlfLines,
+		trailingNewline: bytes.HasSuffix(content, lf),
+	}
+}
+
+// apply rewrites the content to use the line endings, normalising any mixed line endings along the way.
+func (le lineEndings) apply(content []byte) []byte {
+	if !le.known {
+		return content
+	}
+
+	content = bytes.ReplaceAll(content, crlf, lf)
+
+	switch {
+	case le.trailingNewline && !bytes.HasSuffix(content, lf):
+		content = append(content, lf...)
+	case !le.trailingNewline && bytes.HasSuffix(content, lf):
+		content = content[:len(content)-1]
+	}
+
+	if le.crlf {
+		content = bytes.ReplaceAll(content, lf, crlf)
+	}
+
+	return content
+}
@@ -22,9 +22,11 @@ THE SOFTWARE.
 package writer
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -35,6 +37,14 @@ const (
 	EndInjection   string = "<!-- END GHA DOCS -->"
 )
 
+const (
+	BeginCatalogInjection string = "<!-- BEGIN GHA DOCS CATALOG -->"
+	EndCatalogInjection   string = "<!-- END GHA DOCS CATALOG -->"
+)
+
+// defaultFileMode is used when writing a file that doesn't already exist.
+const defaultFileMode os.FileMode = 0644
+
 type stdoutWriter struct{}
 
 func (sw stdoutWriter) Write(content []byte) (int, error) {
@@ -42,65 +52,145 @@ func (sw stdoutWriter) Write(content []byte) (int, error) {
 }
 
 type fileWriter struct {
-	file   string
-	inject bool
+	file        string
+	inject      bool
+	beginMarker string
+	endMarker   string
 }
 
+// Write writes content to the file, returning the length of the content passed in, as io.Writer requires, even though
+// more may be written to the file after injecting or converting line endings.
 func (fw fileWriter) Write(content []byte) (int, error) {
-	if !fw.inject {
-		return fw.writeFile(content)
+	if err := fw.write(content); err != nil {
+		return 0, err
+	}
+
+	return len(content), nil
+}
+
+func (fw fileWriter) write(content []byte) error {
+	existing, err := os.ReadFile(fw.file)
+	if err != nil {
+		// If the file can't be read, treat it as not existing and write as per normal.
+		return fw.writeFile(content, nil)
 	}
 
-	existingFileContent, err := os.ReadFile(fw.file)
-	if err != nil || len(existingFileContent) == 0 {
-		// Even if inject flag is passed, if file doesn't exist OR is empty, then write as per normal.
-		return fw.writeFile(content)
+	if !fw.inject || len(existing) == 0 {
+		// Even if inject flag is passed, if file is empty, then write as per normal.
+		return fw.writeFile(content, existing)
 	}
 
-	return fw.injectContent(string(existingFileContent), string(content))
+	return fw.injectContent(existing, string(content))
 }
 
-func (fw fileWriter) injectContent(existing, newContent string) (int, error) {
-	beginIdx := strings.Index(existing, BeginInjection)
-	endIdx := strings.Index(existing, EndInjection)
+func (fw fileWriter) injectContent(existing []byte, newContent string) error {
+	existingContent := string(existing)
+
+	beginIdx := strings.Index(existingContent, fw.beginMarker)
+	endIdx := strings.Index(existingContent, fw.endMarker)
 
 	if beginIdx == -1 {
-		return 0, errors.New(fmt.Sprintf("missing begin injection marker: %s", BeginInjection))
+		return errors.New(fmt.Sprintf("missing begin injection marker: %s", fw.beginMarker))
 	}
 
 	if endIdx == -1 {
-		return 0, errors.New(fmt.Sprintf("missing end injection marker: %s", EndInjection))
+		return errors.New(fmt.Sprintf("missing end injection marker: %s", fw.endMarker))
 	}
 
 	if endIdx < beginIdx {
-		return 0, errors.New("end injection marker is before begin injection marker")
+		return errors.New("end injection marker is before begin injection marker")
+	}
+
+	injectedContent := existingContent[:beginIdx+len(fw.beginMarker)] + "\n" + newContent + existingContent[endIdx:]
+
+	return fw.writeFile([]byte(injectedContent), existing)
+}
+
+// writeFile writes content to the file, matching the line ending conventions of any existing content. The write
+// goes via a temporary file in the same directory which is then renamed over the target, so the file is never left
+// partially written. Nothing is written if the file already holds exactly the content to be written.
+func (fw fileWriter) writeFile(content, existing []byte) error {
+	mode := defaultFileMode
+
+	if existing != nil {
+		content = detectLineEndings(existing).apply(content)
+
+		if bytes.Equal(content, existing) {
+			return nil
+		}
 	}
 
-	injectedContent := existing[:beginIdx+len(BeginInjection)] + "\n" + newContent + existing[endIdx:]
+	if info, err := os.Stat(fw.file); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	if err := writeFileAtomic(fw.file, content, mode); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("couldn't write to file: %s", fw.file))
+	}
 
-	return fw.writeFile([]byte(injectedContent))
+	return nil
 }
 
-func (fw fileWriter) writeFile(content []byte) (int, error) {
-	err := os.WriteFile(fw.file, []byte(content), 0644)
+func writeFileAtomic(file string, content []byte, mode os.FileMode) error {
+	// Write to the file a symlink points to, rather than replacing the symlink with the renamed file.
+	if target, err := filepath.EvalSymlinks(file); err == nil {
+		file = target
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(file), fmt.Sprintf(".%s.*.tmp", filepath.Base(file)))
 	if err != nil {
-		return 0, errors.Wrap(err, fmt.Sprintf("couldn't write to file: %s", fw.file))
+		return err
 	}
 
-	return len(content), err
+	// Removing the temporary file is a no-op once it has been renamed.
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), file)
 }
 
 type WriteInputs struct {
 	Content    string
 	OutputFile string
 	Inject     bool
+	// BeginMarker and EndMarker are the markers content is injected between, which default to BeginInjection and
+	// EndInjection.
+	BeginMarker string
+	EndMarker   string
 }
 
 func Write(inputs WriteInputs) error {
 	var w io.Writer
 
 	if inputs.OutputFile != "" {
-		w = fileWriter{inputs.OutputFile, inputs.Inject}
+		fw := fileWriter{inputs.OutputFile, inputs.Inject, inputs.BeginMarker, inputs.EndMarker}
+
+		if fw.beginMarker == "" {
+			fw.beginMarker = BeginInjection
+		}
+
+		if fw.endMarker == "" {
+			fw.endMarker = EndInjection
+		}
+
+		w = fw
 	} else {
 		w = stdoutWriter{}
 	}
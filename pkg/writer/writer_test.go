@@ -26,7 +26,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -141,3 +143,190 @@ func TestFileWriterInjectInvalid(t *testing.T) {
 		assert.Contains(t, err.Error(), tc.expectedErrMsg)
 	}
 }
+
+func TestFileWriterPreservesLineEndings(t *testing.T) {
+	t.Parallel()
+
+	content := "first\nsecond\n"
+
+	testCases := []struct {
+		name            string
+		existing        string
+		inject          bool
+		expectedContent string
+	}{
+		{"lf_overwrite", "old\n", false, "first\nsecond\n"},
+		{"crlf_overwrite", "old\r\nlines\r\n", false, "first\r\nsecond\r\n"},
+		{"no_trailing_newline_overwrite", "old\nlines", false, "first\nsecond"},
+		{
+			"crlf_inject",
+			fmt.Sprintf("above\r\n%s\r\nold\r\n%s\r\nbelow\r\n", writer.BeginInjection, writer.EndInjection),
+			true,
+			fmt.Sprintf("above\r\n%s\r\nfirst\r\nsecond\r\n%s\r\nbelow\r\n", writer.BeginInjection, writer.EndInjection),
+		},
+		{
+			"crlf_no_trailing_newline_inject",
+			fmt.Sprintf("%s\r\n%s", writer.BeginInjection, writer.EndInjection),
+			true,
+			fmt.Sprintf("%s\r\nfirst\r\nsecond\r\n%s", writer.BeginInjection, writer.EndInjection),
+		},
+	}
+
+	for _, tc := range testCases {
+		outputFile := filepath.Join(t.TempDir(), "README.md")
+
+		if err := os.WriteFile(outputFile, []byte(tc.existing), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		err := writer.Write(writer.WriteInputs{Content: content, OutputFile: outputFile, Inject: tc.inject})
+		if err != nil {
+			t.Fatalf("Test %s: %v", tc.name, err)
+		}
+
+		got, err := os.ReadFile(outputFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, tc.expectedContent, string(got), tc.name)
+	}
+}
+
+func TestFileWriterPreservesMode(t *testing.T) {
+	t.Parallel()
+
+	outputFile := filepath.Join(t.TempDir(), "README.md")
+
+	if err := os.WriteFile(outputFile, []byte("old\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writer.Write(writer.WriteInputs{Content: "new\n", OutputFile: outputFile}); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestFileWriterWritesThroughSymlink(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	targetFile := filepath.Join(dir, "docs", "README.md")
+	outputFile := filepath.Join(dir, "README.md")
+
+	if err := os.MkdirAll(filepath.Dir(targetFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(targetFile, []byte("old\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink(filepath.Join("docs", "README.md"), outputFile); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writer.Write(writer.WriteInputs{Content: "new\n", OutputFile: outputFile}); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Lstat(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotZero(t, info.Mode()&os.ModeSymlink)
+
+	got, err := os.ReadFile(targetFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "new\n", string(got))
+}
+
+func TestFileWriterSkipsUnchangedContent(t *testing.T) {
+	t.Parallel()
+
+	outputFile := filepath.Join(t.TempDir(), "README.md")
+	content := fmt.Sprintf("%s\nunchanged\n%s\n", writer.BeginInjection, writer.EndInjection)
+
+	if err := os.WriteFile(outputFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(outputFile, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writer.Write(writer.WriteInputs{Content: "unchanged\n", OutputFile: outputFile, Inject: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, info.ModTime().Equal(past))
+
+	entries, err := os.ReadDir(filepath.Dir(outputFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, entries, 1)
+}
+
+func TestFileWriterInjectCustomMarkers(t *testing.T) {
+	t.Parallel()
+
+	outputFile := filepath.Join(t.TempDir(), "README.md")
+	existing := fmt.Sprintf(
+		"%s\nold docs\n%s\n%s\nold catalog\n%s\n",
+		writer.BeginInjection,
+		writer.EndInjection,
+		writer.BeginCatalogInjection,
+		writer.EndCatalogInjection,
+	)
+
+	if err := os.WriteFile(outputFile, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := writer.Write(writer.WriteInputs{
+		Content:     "new catalog\n",
+		OutputFile:  outputFile,
+		Inject:      true,
+		BeginMarker: writer.BeginCatalogInjection,
+		EndMarker:   writer.EndCatalogInjection,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(
+		t,
+		fmt.Sprintf(
+			"%s\nold docs\n%s\n%s\nnew catalog\n%s\n",
+			writer.BeginInjection,
+			writer.EndInjection,
+			writer.BeginCatalogInjection,
+			writer.EndCatalogInjection,
+		),
+		string(got),
+	)
+}